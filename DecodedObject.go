@@ -1,38 +1,118 @@
 package bencode
 
 import (
-	"bytes"
+	"crypto/sha256"
+	"time"
 )
 
 // DecodedObject is a decoded object with some meta-data.
+//
+// FilePath is the path of the source file in the file system of the
+// operating system. FSName is the name of the source file in a file system
+// given to ParseFS; the file path is not set for such an object, so that it
+// is never written into the working folder by mistake.
+//
+// FileSize is the size of the source file, FileModTime is its modification
+// time, if known. Compression is the compression format of the file; the
+// source data is the decompressed data. SourceSHA256 is the SHA-256 hash sum
+// of the source data, it is kept even when the source data is discarded.
+// DecodeDuration is the time spent on decoding, decompression is not counted.
 type DecodedObject struct {
 	FilePath        string
+	FSName          string
 	SourceData      []byte
 	RawObject       any
-	DecodeTimestamp int64
+	DecodeTimestamp time.Time
 	IsSelfChecked   bool
+	FileSize        int64
+	FileModTime     time.Time
+	SourceSHA256    [sha256.Size]byte
+	DecodeDuration  time.Duration
+	Statistics      Statistics
+	Compression     Compression
+}
+
+// Statistics is a statistics of the nodes of a decoded tree.
+//
+// MaxDepth is the depth of the deepest node, the root node has zero depth.
+// LargestByteString is the length of the longest byte string, keys of
+// dictionaries are not counted as byte strings.
+type Statistics struct {
+	Integers          int
+	ByteStrings       int
+	Lists             int
+	Dictionaries      int
+	MaxDepth          int
+	LargestByteString int
 }
 
 // MakeSelfCheck performs a simple self-check. It encodes the decoded data and
 // compares it with the source.
 func (do *DecodedObject) MakeSelfCheck() (success bool) {
+	return do.checkEncoding() == nil
+}
 
-	// Encode the decoded data.
-	var err error
-	var baEncoded []byte
-	baEncoded, err = NewEncoder().EncodeAnInterface(do.RawObject)
-	if err != nil {
-		return false
+// MakeSelfCheckReport performs a self-check and explains its failure. It
+// encodes the decoded data and compares it with the source on the fly, so
+// that the encoded data is never kept in memory; encoding stops shortly
+// after the first mismatch. Keys which are not sorted or are duplicated are
+// encoded as they were decoded and pass the comparison, but they are not
+// canonical, so they are reported when the data is the same. Nil is returned
+// when the self-check is passed and all the keys are canonical.
+func (do *DecodedObject) MakeSelfCheckReport() (report *SelfCheckReport) {
+	report = do.checkEncoding()
+	if report != nil {
+		return report
 	}
 
-	// Compare the encoded decoded data with the original data.
-	var checkResult int
-	checkResult = bytes.Compare(baEncoded, do.SourceData)
-	if checkResult != 0 {
-		return false
+	return newKeyOrderReport(do.SourceData)
+}
+
+// checkEncoding encodes the decoded data, compares it with the source and
+// explains the first difference. Nil is returned when the data is the same.
+func (do *DecodedObject) checkEncoding() (report *SelfCheckReport) {
+	var cw = newComparingWriter(do.SourceData)
+	var err = NewEncoder().EncodeTo(cw, do.RawObject)
+
+	report = newSelfCheckReport(do.RawObject, cw, err)
+	if report != nil {
+		return report
 	}
 
 	do.IsSelfChecked = true
 
-	return true
+	return nil
+}
+
+// collectStatistics collects the statistics of a decoded tree.
+func collectStatistics(rawObject any) (statistics Statistics) {
+	statistics.addNode(rawObject, 0)
+
+	return statistics
+}
+
+// addNode adds a node and its children to the statistics.
+func (s *Statistics) addNode(node any, depth int) {
+	s.MaxDepth = max(s.MaxDepth, depth)
+
+	switch x := node.(type) {
+	case int64:
+		s.Integers++
+
+	case []byte:
+		s.ByteStrings++
+		s.LargestByteString = max(s.LargestByteString, len(x))
+
+	case []any:
+		s.Lists++
+		for _, item := range x {
+			s.addNode(item, depth+1)
+		}
+
+	case []DictionaryItem:
+		s.Dictionaries++
+		for _, item := range x {
+			s.addNode(item.Value, depth+1)
+		}
+	}
 }
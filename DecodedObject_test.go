@@ -67,3 +67,22 @@ func Test_DecodedObject_MakeSelfCheck(t *testing.T) {
 		aTest.MustBeEqual(ok, false)
 	}
 }
+
+func Test_collectStatistics(t *testing.T) {
+	var aTest = tester.New(t)
+
+	// Test #1. Scalar.
+	aTest.MustBeEqual(collectStatistics(int64(1)), Statistics{Integers: 1})
+
+	// Test #2. Tree.
+	var object, err = DecodeBytes[any]([]byte("d4:infod5:filesld6:lengthi1eeee4:name5:helloe"))
+	aTest.MustBeNoError(err)
+	aTest.MustBeEqual(collectStatistics(object), Statistics{
+		Integers:          1,
+		ByteStrings:       1,
+		Lists:             1,
+		Dictionaries:      3,
+		MaxDepth:          4,
+		LargestByteString: 5,
+	})
+}
@@ -2,8 +2,10 @@ package bencode
 
 import (
 	"bufio"
-	"bytes"
 	"fmt"
+	"io"
+	"math"
+	"slices"
 )
 
 //	1.	Parser's settings.
@@ -33,25 +35,147 @@ func (d Decoder) Decode() (result any, err error) {
 	return d.readBencodedValue()
 }
 
+// DecodeValue decodes a 'bencoded' byte stream into a strongly typed value.
+func (d Decoder) DecodeValue() (result Value, err error) {
+	var raw any
+	raw, err = d.readBencodedValue()
+	if err != nil {
+		return nil, err
+	}
+
+	return ToValue(raw)
+}
+
 // readBencodedValue reads a raw "bencoded" value, including its sub-values.
 func (d Decoder) readBencodedValue() (result any, err error) {
+	return readBencodedValue(d.source())
+}
+
+// readByteString reads a byte string from the stream (reader).
+func (d Decoder) readByteString() (ba []byte, err error) {
+	return readByteString(d.source())
+}
+
+// readByteStringSizeHeader reads the size header of a byte string from the
+// stream (reader) and converts its value into an integer.
+func (d Decoder) readByteStringSizeHeader() (byteStringLen uint, err error) {
+	return readByteStringSizeHeader(d.reader)
+}
+
+// readDictionary reads a dictionary. We suppose that the header of the
+// dictionary ('d') has already been read from the stream.
+func (d Decoder) readDictionary() (result any, err error) {
+	return readDictionary(d.source())
+}
+
+// readDictionaryKey reads a dictionary's key.
+func (d Decoder) readDictionaryKey() ([]byte, error) {
+	return d.readByteString()
+}
+
+// readDictionaryValue reads a dictionary's value.
+func (d Decoder) readDictionaryValue() (any, error) {
+	return d.readBencodedValue()
+}
+
+// readInteger reads an integer from the stream (reader). We suppose that the
+// header of the integer ('i') has already been read from the stream.
+func (d Decoder) readInteger() (value int64, err error) {
+	return readInteger(d.reader)
+}
+
+// readList reads a list from the stream (reader). We suppose that the header
+// of the list ('l') has already been read from the stream.
+func (d Decoder) readList() (list []any, err error) {
+	return readList(d.source())
+}
+
+// skipBencodedValue reads a value, including its sub-values, without keeping
+// it. Byte strings are discarded without being copied.
+func (d Decoder) skipBencodedValue() (err error) {
+	return skipBencodedValue(d.source())
+}
+
+// source returns the stream as a source of data.
+func (d Decoder) source() byteSource {
+	return streamSource{d.reader}
+}
+
+// byteStringChunkSize is the size of chunks in which long byte strings are
+// read from a stream.
+const byteStringChunkSize = 64 * 1024
+
+// byteSource is a source of "bencoded" data. The reading routines below are
+// shared by the Decoder reading a stream and by the decoder of memory-mapped
+// data reading a byte slice, so that both follow the same rules and return
+// the same errors.
+type byteSource interface {
+	io.ByteScanner
+
+	// readBytes reads the contents of a byte string of the given length.
+	readBytes(n uint) (ba []byte, err error)
+
+	// discardBytes skips the contents of a byte string of the given length.
+	discardBytes(n uint) (err error)
+}
+
+// streamSource is a source of data reading a stream.
+type streamSource struct {
+	*bufio.Reader
+}
+
+// readBytes reads the contents of a byte string. Short byte strings are read
+// into an array of their size. Long ones are read in chunks, so that a huge
+// size header does not allocate the memory before the data is read.
+func (ss streamSource) readBytes(n uint) (ba []byte, err error) {
+	ba = make([]byte, 0, min(n, byteStringChunkSize))
+	for uint(len(ba)) < n {
+		var chunkSize = int(min(n-uint(len(ba)), byteStringChunkSize))
+		ba = slices.Grow(ba, chunkSize)
+
+		_, err = io.ReadFull(ss.Reader, ba[len(ba):len(ba)+chunkSize])
+		if err == io.ErrUnexpectedEOF {
+			return nil, io.EOF
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		ba = ba[:len(ba)+chunkSize]
+	}
+
+	return ba, nil
+}
+
+// discardBytes skips the contents of a byte string.
+func (ss streamSource) discardBytes(n uint) (err error) {
+	if n > math.MaxInt {
+		return fmt.Errorf(ErrHeaderLength, n)
+	}
+
+	_, err = ss.Discard(int(n))
+	return err
+}
+
+// readBencodedValue reads a raw "bencoded" value, including its sub-values.
+func readBencodedValue(src byteSource) (result any, err error) {
 
 	// Get the first byte from stream to know its type.
 	var b byte
-	b, err = d.reader.ReadByte()
+	b, err = src.ReadByte()
 	if err != nil {
 		return nil, err
 	}
 
 	// Analyze the type.
 	if b == HeaderDictionary {
-		return d.readDictionary()
+		return readDictionary(src)
 
 	} else if b == HeaderList {
-		return d.readList()
+		return readList(src)
 
 	} else if b == HeaderInteger {
-		return d.readInteger()
+		return readInteger(src)
 
 	} else if isByteNonNegativeAsciiNumeric(b) {
 		// It must be an ASCII number indicating a byte string.
@@ -59,13 +183,13 @@ func (d Decoder) readBencodedValue() (result any, err error) {
 
 		// Rewind the Cursor back, as it does not have a type-Prefix !
 		// The 'bencode' encoding is ugly ...
-		err = d.reader.UnreadByte()
+		err = src.UnreadByte()
 		if err != nil {
 			return nil, err
 		}
 
 		// Read the byte string.
-		return d.readByteString()
+		return readByteString(src)
 	}
 
 	// Otherwise, it is a syntax error.
@@ -74,55 +198,32 @@ func (d Decoder) readBencodedValue() (result any, err error) {
 	return nil, fmt.Errorf(ErrFSyntaxErrorAt, errorArea)
 }
 
-// readByteString reads a byte string from the stream (reader).
-func (d Decoder) readByteString() (ba []byte, err error) {
+// readByteString reads a byte string.
+func readByteString(src byteSource) (ba []byte, err error) {
 
 	// Read the size header and verify it.
 	var byteStringLen uint
-	byteStringLen, err = d.readByteStringSizeHeader()
+	byteStringLen, err = readByteStringSizeHeader(src)
 	if err != nil {
 		return nil, err
 	}
 
 	// Now we should read the byte string.
-	var b byte
-	var i uint = 0
-	var bytesAccumulator *bytes.Buffer
-	bytesAccumulator = bytes.NewBuffer([]byte{})
-	for i < byteStringLen {
-
-		// Read the next symbol.
-		b, err = d.reader.ReadByte()
-		if err != nil {
-			return nil, err
-		}
-
-		// Save the byte to the accumulator.
-		err = bytesAccumulator.WriteByte(b)
-		if err != nil {
-			return nil, err
-		}
-
-		i++
-	}
-
-	ba = bytesAccumulator.Bytes()
-
-	return ba, nil
+	return src.readBytes(byteStringLen)
 }
 
-// readByteStringSizeHeader reads the size header of a byte string from the
-// stream (reader) and converts its value into an integer.
-func (d Decoder) readByteStringSizeHeader() (byteStringLen uint, err error) {
+// readByteStringSizeHeader reads the size header of a byte string and
+// converts its value into an integer.
+func readByteStringSizeHeader(src io.ByteReader) (byteStringLen uint, err error) {
 
 	// Read the first byte.
 	var b byte
-	b, err = d.reader.ReadByte()
+	b, err = src.ReadByte()
 	if err != nil {
 		return 0, err
 	}
 
-	var sizeHeader []byte
+	var sizeHeader = make([]byte, 0, ByteStringSizeHeaderMaxLength)
 	for b != HeaderStringSizeValueDelimiter {
 
 		// Syntax check.
@@ -143,7 +244,7 @@ func (d Decoder) readByteStringSizeHeader() (byteStringLen uint, err error) {
 		}
 
 		// Read the next byte.
-		b, err = d.reader.ReadByte()
+		b, err = src.ReadByte()
 		if err != nil {
 			return 0, err
 		}
@@ -167,15 +268,15 @@ func (d Decoder) readByteStringSizeHeader() (byteStringLen uint, err error) {
 }
 
 // readDictionary reads a dictionary. We suppose that the header of the
-// dictionary ('d') has already been read from the stream.
-func (d Decoder) readDictionary() (result any, err error) {
+// dictionary ('d') has already been read.
+func readDictionary(src byteSource) (result any, err error) {
 
 	// Prepare the data.
 	var dictionary = make([]DictionaryItem, 0)
 
 	// Probe the next byte to check the end of the dictionary.
 	var b byte
-	b, err = d.reader.ReadByte()
+	b, err = src.ReadByte()
 	if err != nil {
 		return nil, err
 	}
@@ -184,21 +285,21 @@ func (d Decoder) readDictionary() (result any, err error) {
 
 		// That single byte (we probed) was not an End !
 		// We must get back, rewind that byte.
-		err = d.reader.UnreadByte()
+		err = src.UnreadByte()
 		if err != nil {
 			return nil, err
 		}
 
 		// Get the key.
 		var dictKey []byte
-		dictKey, err = d.readDictionaryKey()
+		dictKey, err = readByteString(src)
 		if err != nil {
 			return nil, err
 		}
 
 		// Get the value.
 		var dictValue any
-		dictValue, err = d.readDictionaryValue()
+		dictValue, err = readBencodedValue(src)
 		if err != nil {
 			return nil, err
 		}
@@ -218,7 +319,7 @@ func (d Decoder) readDictionary() (result any, err error) {
 		)
 
 		// Probe the next byte to check the end of the dictionary.
-		b, err = d.reader.ReadByte()
+		b, err = src.ReadByte()
 		if err != nil {
 			return nil, err
 		}
@@ -227,26 +328,16 @@ func (d Decoder) readDictionary() (result any, err error) {
 	return dictionary, nil
 }
 
-// readDictionaryKey reads a dictionary's key.
-func (d Decoder) readDictionaryKey() ([]byte, error) {
-	return d.readByteString()
-}
-
-// readDictionaryValue reads a dictionary's value.
-func (d Decoder) readDictionaryValue() (any, error) {
-	return d.readBencodedValue()
-}
-
-// readInteger reads an integer from the stream (reader). We suppose that the
-// header of the integer ('i') has already been read from the stream.
-func (d Decoder) readInteger() (value int64, err error) {
+// readInteger reads an integer. We suppose that the header of the integer
+// ('i') has already been read.
+func readInteger(src io.ByteReader) (value int64, err error) {
 
 	// Prepare the data.
-	var valueBA []byte
+	var valueBA = make([]byte, 0, IntegerMaxLength)
 
 	// Read the first byte.
 	var b byte
-	b, err = d.reader.ReadByte()
+	b, err = src.ReadByte()
 	if err != nil {
 		return 0, err
 	}
@@ -271,7 +362,7 @@ func (d Decoder) readInteger() (value int64, err error) {
 		}
 
 		// Read the next byte.
-		b, err = d.reader.ReadByte()
+		b, err = src.ReadByte()
 		if err != nil {
 			return 0, err
 		}
@@ -289,16 +380,16 @@ func (d Decoder) readInteger() (value int64, err error) {
 	return convertByteStringToInteger(valueBA)
 }
 
-// readList reads a list from the stream (reader). We suppose that the header
-// of the list ('l') has already been read from the stream.
-func (d Decoder) readList() (list []any, err error) {
+// readList reads a list. We suppose that the header of the list ('l') has
+// already been read.
+func readList(src byteSource) (list []any, err error) {
 
 	// Prepare the data.
 	list = make([]any, 0)
 
 	// Probe the next byte to check the end of the list.
 	var b byte
-	b, err = d.reader.ReadByte()
+	b, err = src.ReadByte()
 	if err != nil {
 		return nil, err
 	}
@@ -307,23 +398,23 @@ func (d Decoder) readList() (list []any, err error) {
 
 		// That single byte (we probed) was not an End !
 		// We must get back, rewind that byte.
-		err = d.reader.UnreadByte()
+		err = src.UnreadByte()
 		if err != nil {
 			return nil, err
 		}
 
 		// Get the item.
 		var listItem any
-		listItem, err = d.readBencodedValue()
+		listItem, err = readBencodedValue(src)
 		if err != nil {
 			return nil, err
 		}
 
-		// Save the item into the dictionary.
+		// Save the item into the list.
 		list = append(list, listItem)
 
 		// Probe the next byte to check the end of the list.
-		b, err = d.reader.ReadByte()
+		b, err = src.ReadByte()
 		if err != nil {
 			return nil, err
 		}
@@ -331,3 +422,80 @@ func (d Decoder) readList() (list []any, err error) {
 
 	return list, nil
 }
+
+// skipBencodedValue reads a value, including its sub-values, without keeping
+// it. Byte strings are discarded without being copied.
+func skipBencodedValue(src byteSource) (err error) {
+	var b byte
+	b, err = src.ReadByte()
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case (b == HeaderDictionary) || (b == HeaderList):
+		for {
+			var isEnd bool
+			isEnd, err = probeEnd(src)
+			if err != nil {
+				return err
+			}
+			if isEnd {
+				return nil
+			}
+
+			if b == HeaderDictionary {
+				err = skipByteString(src)
+				if err != nil {
+					return err
+				}
+			}
+
+			err = skipBencodedValue(src)
+			if err != nil {
+				return err
+			}
+		}
+
+	case b == HeaderInteger:
+		_, err = readInteger(src)
+		return err
+
+	case isByteNonNegativeAsciiNumeric(b):
+		err = src.UnreadByte()
+		if err != nil {
+			return err
+		}
+
+		return skipByteString(src)
+	}
+
+	return fmt.Errorf(ErrFSyntaxErrorAt, []byte{b})
+}
+
+// skipByteString reads a byte string without keeping it.
+func skipByteString(src byteSource) (err error) {
+	var byteStringLen uint
+	byteStringLen, err = readByteStringSizeHeader(src)
+	if err != nil {
+		return err
+	}
+
+	return src.discardBytes(byteStringLen)
+}
+
+// probeEnd checks whether the next byte is the footer of a list or a
+// dictionary. The footer is consumed, any other byte is left in the source.
+func probeEnd(src io.ByteScanner) (isEnd bool, err error) {
+	var b byte
+	b, err = src.ReadByte()
+	if err != nil {
+		return false, err
+	}
+
+	if b == FooterCommon {
+		return true, nil
+	}
+
+	return false, src.UnreadByte()
+}
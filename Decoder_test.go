@@ -3,6 +3,8 @@ package bencode
 import (
 	"bufio"
 	"fmt"
+	"io"
+	"math"
 	"strings"
 	"testing"
 
@@ -183,6 +185,38 @@ func Test_Decoder_readByteString(t *testing.T) {
 	}
 }
 
+func Test_streamSource_readBytes(t *testing.T) {
+	var aTest = tester.New(t)
+
+	// Test #1. Positive: short byte strings take no extra memory.
+	{
+		result, err := NewDecoder(bufio.NewReader(strings.NewReader("d3:key5:valuee"))).readBencodedValue()
+		aTest.MustBeNoError(err)
+
+		var item = result.([]DictionaryItem)[0]
+		aTest.MustBeEqual(cap(item.Key), len(item.Key))
+		aTest.MustBeEqual(cap(item.Value.([]byte)), len(item.Value.([]byte)))
+
+		ba, err := streamSource{bufio.NewReader(strings.NewReader(""))}.readBytes(0)
+		aTest.MustBeNoError(err)
+		aTest.MustBeEqual(ba, []byte{})
+	}
+
+	// Test #2. Positive: a long byte string is read in chunks.
+	{
+		var data = strings.Repeat("abcdefgh", byteStringChunkSize/4+1)
+		ba, err := streamSource{bufio.NewReader(strings.NewReader(data))}.readBytes(uint(len(data)))
+		aTest.MustBeNoError(err)
+		aTest.MustBeEqual(string(ba), data)
+	}
+
+	// Test #3. Negative: a huge size header is not allocated.
+	{
+		_, err := streamSource{bufio.NewReader(strings.NewReader("abc"))}.readBytes(math.MaxUint)
+		aTest.MustBeEqual(err, io.EOF)
+	}
+}
+
 func Test_Decoder_readByteStringSizeHeader(t *testing.T) {
 
 	type TestData struct {
@@ -0,0 +1,128 @@
+package bencode
+
+import (
+	"iter"
+	"slices"
+	"strings"
+)
+
+// DictionaryBinarySearchMinSize is the minimal number of items in a
+// dictionary for which the key lookup uses the binary search.
+const DictionaryBinarySearchMinSize = 16
+
+// Dictionary is a 'bencode' dictionary with lookup and modification methods.
+// It is a named form of the dictionary returned by the decoder, so that a
+// decoded dictionary is converted without copying:
+//
+//	var dictionary = Dictionary(decoded.([]DictionaryItem))
+//
+// According to the specification, keys of a dictionary are sorted, and the
+// lookup of large dictionaries relies on it: the binary search is used for
+// them. Small dictionaries are searched with a linear scan. A decoded
+// dictionary which is not encoded canonically may be checked with IsSorted.
+type Dictionary []DictionaryItem
+
+// Get returns the value of the key.
+func (d Dictionary) Get(key string) (value any, ok bool) {
+	var i = d.index(key)
+	if i < 0 {
+		return nil, false
+	}
+
+	return d[i].Value, true
+}
+
+// Has checks whether the key exists.
+func (d Dictionary) Has(key string) bool {
+	return d.index(key) >= 0
+}
+
+// Set sets the value of the key. A new key is inserted at the position which
+// keeps the canonical order of keys.
+func (d *Dictionary) Set(key string, value any) {
+	var item = DictionaryItem{
+		Key:      []byte(key),
+		Value:    value,
+		KeyStr:   key,
+		ValueStr: convertInterfaceToString(value),
+	}
+
+	var i = d.index(key)
+	if i >= 0 {
+		(*d)[i] = item
+		return
+	}
+
+	i, _ = slices.BinarySearchFunc(*d, key, compareItemKey)
+	*d = slices.Insert(*d, i, item)
+}
+
+// Delete deletes the key and returns true if the key existed. If the key is
+// duplicated, all its items are deleted.
+func (d *Dictionary) Delete(key string) (ok bool) {
+	var oldLen = len(*d)
+	*d = slices.DeleteFunc(*d, func(item DictionaryItem) bool {
+		return string(item.Key) == key
+	})
+
+	return len(*d) < oldLen
+}
+
+// Keys returns keys of the dictionary in their order.
+func (d Dictionary) Keys() (keys []string) {
+	keys = make([]string, 0, len(d))
+	for _, item := range d {
+		keys = append(keys, string(item.Key))
+	}
+
+	return keys
+}
+
+// All returns an iterator over keys and values of the dictionary in their
+// order.
+func (d Dictionary) All() iter.Seq2[string, any] {
+	return func(yield func(string, any) bool) {
+		for _, item := range d {
+			if !yield(string(item.Key), item.Value) {
+				return
+			}
+		}
+	}
+}
+
+// IsSorted checks whether keys of the dictionary are sorted and unique, as
+// required by the specification.
+func (d Dictionary) IsSorted() bool {
+	for i := 1; i < len(d); i++ {
+		if string(d[i-1].Key) >= string(d[i].Key) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// index returns the index of the first item with the key or -1.
+func (d Dictionary) index(key string) int {
+	if len(d) >= DictionaryBinarySearchMinSize {
+		var i, found = slices.BinarySearchFunc(d, key, compareItemKey)
+		if found {
+			return i
+		}
+
+		return -1
+	}
+
+	for i := range d {
+		if string(d[i].Key) == key {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// compareItemKey compares the key of an item with a key.
+func compareItemKey(item DictionaryItem, key string) int {
+	return strings.Compare(string(item.Key), key)
+}
@@ -0,0 +1,127 @@
+package bencode
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/vault-thirteen/auxie/tester"
+)
+
+func Test_Dictionary_Get(t *testing.T) {
+	var aTest = tester.New(t)
+
+	// Test #1. Small dictionary.
+	{
+		var d = Dictionary{
+			{Key: []byte("b"), Value: int64(2)},
+			{Key: []byte("a"), Value: int64(1)},
+		}
+
+		value, ok := d.Get("a")
+		aTest.MustBeEqual(ok, true)
+		aTest.MustBeEqual(value, int64(1))
+
+		_, ok = d.Get("c")
+		aTest.MustBeEqual(ok, false)
+		aTest.MustBeEqual(d.Has("b"), true)
+		aTest.MustBeEqual(d.Has("c"), false)
+	}
+
+	// Test #2. Large dictionary.
+	{
+		var d Dictionary
+		for i := 0; i < DictionaryBinarySearchMinSize*2; i++ {
+			d = append(d, DictionaryItem{Key: fmt.Appendf(nil, "k%03d", i), Value: int64(i)})
+		}
+
+		value, ok := d.Get("k017")
+		aTest.MustBeEqual(ok, true)
+		aTest.MustBeEqual(value, int64(17))
+		aTest.MustBeEqual(d.Has("k100"), false)
+		aTest.MustBeEqual(d.Has("k0170"), false)
+	}
+
+	// Test #3. Large dictionary: misses and insertions.
+	{
+		var d Dictionary
+		for i := 0; i < DictionaryBinarySearchMinSize*4; i += 2 {
+			d.Set(fmt.Sprintf("k%03d", i), int64(i))
+		}
+
+		for i := 1; i < DictionaryBinarySearchMinSize*4; i += 2 {
+			var key = fmt.Sprintf("k%03d", i)
+			aTest.MustBeEqual(d.Has(key), false)
+			d.Set(key, int64(i))
+			aTest.MustBeEqual(d.Has(key), true)
+		}
+
+		aTest.MustBeEqual(len(d), DictionaryBinarySearchMinSize*4)
+		aTest.MustBeEqual(d.IsSorted(), true)
+	}
+}
+
+func Test_Dictionary_Set(t *testing.T) {
+	var aTest = tester.New(t)
+
+	var d Dictionary
+	d.Set("b", []byte("B"))
+	d.Set("d", int64(4))
+	d.Set("a", int64(1))
+	d.Set("c", int64(3))
+	aTest.MustBeEqual(d.Keys(), []string{"a", "b", "c", "d"})
+	aTest.MustBeEqual(d[1].ValueStr, "B")
+	aTest.MustBeEqual(d.IsSorted(), true)
+
+	// Replacement.
+	d.Set("b", int64(2))
+	aTest.MustBeEqual(len(d), 4)
+	aTest.MustBeEqual(d[1], DictionaryItem{Key: []byte("b"), Value: int64(2), KeyStr: "b"})
+
+	encoded, err := NewEncoder().EncodeAnInterface(d)
+	aTest.MustBeNoError(err)
+	aTest.MustBeEqual(string(encoded), "d1:ai1e1:bi2e1:ci3e1:di4ee")
+}
+
+func Test_Dictionary_Delete(t *testing.T) {
+	var aTest = tester.New(t)
+
+	var d = Dictionary{
+		{Key: []byte("a")},
+		{Key: []byte("b")},
+		{Key: []byte("a")},
+	}
+
+	aTest.MustBeEqual(d.Delete("a"), true)
+	aTest.MustBeEqual(d.Keys(), []string{"b"})
+	aTest.MustBeEqual(d.Delete("a"), false)
+}
+
+func Test_Dictionary_All(t *testing.T) {
+	var aTest = tester.New(t)
+
+	var d = Dictionary{
+		{Key: []byte("x"), Value: int64(1)},
+		{Key: []byte("y"), Value: int64(2)},
+		{Key: []byte("z"), Value: int64(3)},
+	}
+
+	var keys []string
+	var sum int64
+	for key, value := range d.All() {
+		if key == "z" {
+			break
+		}
+		keys = append(keys, key)
+		sum += value.(int64)
+	}
+	aTest.MustBeEqual(keys, []string{"x", "y"})
+	aTest.MustBeEqual(sum, int64(3))
+}
+
+func Test_Dictionary_IsSorted(t *testing.T) {
+	var aTest = tester.New(t)
+
+	aTest.MustBeEqual(Dictionary{}.IsSorted(), true)
+	aTest.MustBeEqual(Dictionary{{Key: []byte("b")}, {Key: []byte("a")}}.IsSorted(), false)
+	aTest.MustBeEqual(Dictionary{{Key: []byte("a")}, {Key: []byte("a")}}.IsSorted(), false)
+}
@@ -0,0 +1,186 @@
+package bencode
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// ChangeType is a type of change between two documents.
+type ChangeType byte
+
+// Types of changes.
+const (
+	ChangeAdded ChangeType = iota + 1
+	ChangeRemoved
+	ChangeModified
+)
+
+// String returns the symbol of the change type.
+func (ct ChangeType) String() string {
+	switch ct {
+	case ChangeAdded:
+		return "+"
+	case ChangeRemoved:
+		return "-"
+	case ChangeModified:
+		return "~"
+	}
+
+	return "?"
+}
+
+// Change is a difference between two documents. Path is the key path of the
+// changed node, e.g. 'info.files[0].length'.
+type Change struct {
+	Type     ChangeType
+	Path     string
+	OldValue Value
+	NewValue Value
+}
+
+// String returns a human-readable description of the change. Byte strings
+// are shown as text or in hexadecimal form depending on their contents.
+func (c Change) String() string {
+	var path = c.Path
+	if len(path) == 0 {
+		path = "(root)"
+	}
+
+	switch c.Type {
+	case ChangeAdded:
+		return fmt.Sprintf("%v %s: %s", c.Type, path, describeValue(c.NewValue))
+	case ChangeRemoved:
+		return fmt.Sprintf("%v %s: %s", c.Type, path, describeValue(c.OldValue))
+	}
+
+	return fmt.Sprintf("%v %s: %s → %s", c.Type, path, describeValue(c.OldValue), describeValue(c.NewValue))
+}
+
+// Diff lists the differences between two values, which may be given in any
+// form accepted by ToValue. Dictionaries are compared key by key, lists are
+// compared element by element. Changes are listed in the order of the first
+// document, added dictionary keys follow the existing ones.
+func Diff(a, b any) (changes []Change, err error) {
+	var va, vb Value
+	va, err = ToValue(a)
+	if err != nil {
+		return nil, err
+	}
+
+	vb, err = ToValue(b)
+	if err != nil {
+		return nil, err
+	}
+
+	return diffValues(changes, "", va, vb), nil
+}
+
+// DiffBytes lists the differences between two 'bencoded' documents.
+func DiffBytes(a, b []byte) (changes []Change, err error) {
+	var va, vb Value
+	va, err = DecodeBytes[Value](a)
+	if err != nil {
+		return nil, err
+	}
+
+	vb, err = DecodeBytes[Value](b)
+	if err != nil {
+		return nil, err
+	}
+
+	return diffValues(changes, "", va, vb), nil
+}
+
+// diffValues appends the differences between two values to the list.
+func diffValues(changes []Change, path string, a, b Value) []Change {
+	if valueKind(a) != valueKind(b) {
+		return append(changes, Change{Type: ChangeModified, Path: path, OldValue: a, NewValue: b})
+	}
+
+	switch x := a.(type) {
+	case List:
+		return diffLists(changes, path, x, b.(List))
+
+	case Dict:
+		return diffDicts(changes, path, x, b.(Dict))
+	}
+
+	if compareValues(a, b) != 0 {
+		return append(changes, Change{Type: ChangeModified, Path: path, OldValue: a, NewValue: b})
+	}
+
+	return changes
+}
+
+// diffLists appends the differences between two lists to the list of changes.
+func diffLists(changes []Change, path string, a, b List) []Change {
+	for i := 0; (i < len(a)) || (i < len(b)); i++ {
+		var itemPath = appendPathIndex(path, i)
+
+		switch {
+		case i >= len(b):
+			changes = append(changes, Change{Type: ChangeRemoved, Path: itemPath, OldValue: a[i]})
+		case i >= len(a):
+			changes = append(changes, Change{Type: ChangeAdded, Path: itemPath, NewValue: b[i]})
+		default:
+			changes = diffValues(changes, itemPath, a[i], b[i])
+		}
+	}
+
+	return changes
+}
+
+// diffDicts appends the differences between two dictionaries to the list of
+// changes.
+func diffDicts(changes []Change, path string, a, b Dict) []Change {
+	var valuesA = dictValuesByKey(a)
+	var valuesB = dictValuesByKey(b)
+
+	for _, entry := range a {
+		var entryPath = appendPathKey(path, entry.Key)
+
+		var valueB, ok = valuesB[string(entry.Key)]
+		if !ok {
+			changes = append(changes, Change{Type: ChangeRemoved, Path: entryPath, OldValue: entry.Value})
+			continue
+		}
+
+		changes = diffValues(changes, entryPath, entry.Value, valueB)
+	}
+
+	for _, entry := range b {
+		var _, ok = valuesA[string(entry.Key)]
+		if !ok {
+			var entryPath = appendPathKey(path, entry.Key)
+			changes = append(changes, Change{Type: ChangeAdded, Path: entryPath, NewValue: entry.Value})
+		}
+	}
+
+	return changes
+}
+
+// dictValuesByKey creates a map of dictionary values.
+func dictValuesByKey(dict Dict) (values map[string]Value) {
+	values = make(map[string]Value, len(dict))
+	for _, entry := range dict {
+		values[string(entry.Key)] = entry.Value
+	}
+
+	return values
+}
+
+// describeValue returns a short human-readable description of a value.
+func describeValue(v Value) string {
+	switch x := v.(type) {
+	case Int:
+		return strconv.FormatInt(int64(x), 10)
+	case String:
+		return formatByteString(x)
+	case List:
+		return fmt.Sprintf("list of %d items", len(x))
+	case Dict:
+		return fmt.Sprintf("dictionary of %d items", len(x))
+	}
+
+	return "nothing"
+}
@@ -0,0 +1,99 @@
+package bencode
+
+import (
+	"testing"
+	"time"
+
+	"github.com/vault-thirteen/auxie/tester"
+)
+
+func Test_ChangeType_String(t *testing.T) {
+	var aTest = tester.New(t)
+
+	aTest.MustBeEqual(ChangeAdded.String(), "+")
+	aTest.MustBeEqual(ChangeRemoved.String(), "-")
+	aTest.MustBeEqual(ChangeModified.String(), "~")
+	aTest.MustBeEqual(ChangeType(0).String(), "?")
+}
+
+func Test_Change_String(t *testing.T) {
+	var aTest = tester.New(t)
+
+	aTest.MustBeEqual(Change{Type: ChangeAdded, Path: "a", NewValue: Int(1)}.String(), "+ a: 1")
+	aTest.MustBeEqual(Change{Type: ChangeRemoved, Path: "a[0]", OldValue: List{}}.String(), "- a[0]: list of 0 items")
+	aTest.MustBeEqual(
+		Change{Type: ChangeModified, Path: "info.pieces", OldValue: String{0x01}, NewValue: String("x")}.String(),
+		`~ info.pieces: 0x01 → "x"`,
+	)
+	aTest.MustBeEqual(
+		Change{Type: ChangeModified, OldValue: Dict{}, NewValue: Int(1)}.String(),
+		"~ (root): dictionary of 0 items → 1",
+	)
+}
+
+func Test_Diff(t *testing.T) {
+	var aTest = tester.New(t)
+
+	// Test #1. Differences.
+	{
+		changes, err := Diff(
+			[]DictionaryItem{
+				{Key: []byte("a"), Value: int64(1)},
+				{Key: []byte("b"), Value: []any{[]byte("x"), []byte("y")}},
+				{Key: []byte("c"), Value: []byte("old")},
+				{Key: []byte("d"), Value: int64(4)},
+			},
+			[]DictionaryItem{
+				{Key: []byte("a"), Value: int64(1)},
+				{Key: []byte("b"), Value: []any{[]byte("x")}},
+				{Key: []byte("c"), Value: int64(3)},
+				{Key: []byte("e"), Value: []byte("new")},
+			},
+		)
+		aTest.MustBeNoError(err)
+		aTest.MustBeEqual(changes, []Change{
+			{Type: ChangeRemoved, Path: "b[1]", OldValue: String("y")},
+			{Type: ChangeModified, Path: "c", OldValue: String("old"), NewValue: Int(3)},
+			{Type: ChangeRemoved, Path: "d", OldValue: Int(4)},
+			{Type: ChangeAdded, Path: "e", NewValue: String("new")},
+		})
+	}
+
+	// Test #2. No differences.
+	{
+		changes, err := Diff([]any{int64(1), "a"}, []any{1, []byte("a")})
+		aTest.MustBeNoError(err)
+		aTest.MustBeEqual(len(changes), 0)
+	}
+
+	// Test #3. Negative.
+	{
+		_, err := Diff(time.Time{}, int64(1))
+		aTest.MustBeAnError(err)
+
+		_, err = Diff(int64(1), time.Time{})
+		aTest.MustBeAnError(err)
+	}
+}
+
+func Test_DiffBytes(t *testing.T) {
+	var aTest = tester.New(t)
+
+	// Test #1. Positive.
+	{
+		changes, err := DiffBytes([]byte("d4:infod6:lengthi1eee"), []byte("d4:infod6:lengthi2eee"))
+		aTest.MustBeNoError(err)
+		aTest.MustBeEqual(changes, []Change{
+			{Type: ChangeModified, Path: "info.length", OldValue: Int(1), NewValue: Int(2)},
+		})
+	}
+
+	// Test #2. Negative.
+	{
+		_, err := DiffBytes([]byte("x"), []byte("i1e"))
+		aTest.MustBeAnError(err)
+
+		_, err = DiffBytes([]byte("i1e"), []byte("x"))
+		aTest.MustBeAnError(err)
+	}
+}
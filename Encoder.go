@@ -2,6 +2,7 @@ package bencode
 
 import (
 	"errors"
+	"io"
 	"reflect"
 	"strconv"
 )
@@ -96,6 +97,21 @@ func (e Encoder) createTextFromUInteger(value uint64) []byte {
 // EncodeAnInterface encodes an interface into an array of bytes.
 func (e Encoder) EncodeAnInterface(ifc any) (result []byte, err error) {
 
+	// Objects which are able to encode themselves.
+	var marshaler Marshaler
+	var ok bool
+	marshaler, ok = ifc.(Marshaler)
+	if ok {
+		return marshaler.MarshalBencode()
+	}
+
+	// Strongly typed values.
+	var value Value
+	value, ok = ifc.(Value)
+	if ok {
+		return e.encodeValue(nil, value)
+	}
+
 	// Check the interface's type and encode it accordingly.
 	var ifcType = reflect.TypeOf(ifc).Kind()
 	switch ifcType {
@@ -137,13 +153,92 @@ func (e Encoder) EncodeAnInterface(ifc any) (result []byte, err error) {
 		return e.encodeInterfaceOfInt8(ifc)
 	}
 
-	// N.B.: Unfortunately, Go language does not support generic types,
-	// so we must write a lot of similar code doing the same thing.
-
 	// Unknown type.
 	return nil, errors.New(ErrDataType)
 }
 
+// EncodeTo encodes an interface and writes the result into the writer.
+// Lists, dictionaries and byte strings are written part by part, byte
+// strings are not copied, so that the whole result is never kept in memory.
+// Other values are encoded as with EncodeAnInterface. Writing stops at the
+// first error.
+func (e Encoder) EncodeTo(w io.Writer, ifc any) (err error) {
+	switch x := ifc.(type) {
+	case []byte:
+		_, err = w.Write(e.createSizePrefix(uint64(len(x))))
+		if err != nil {
+			return err
+		}
+
+		_, err = w.Write(x)
+		return err
+
+	case string:
+		_, err = w.Write(e.createSizePrefix(uint64(len(x))))
+		if err != nil {
+			return err
+		}
+
+		_, err = io.WriteString(w, x)
+		return err
+
+	case []any:
+		_, err = w.Write(e.listPrefix)
+		if err != nil {
+			return err
+		}
+
+		for _, listItem := range x {
+			err = e.EncodeTo(w, listItem)
+			if err != nil {
+				return err
+			}
+		}
+
+		_, err = w.Write(e.commonPostfix)
+		return err
+
+	case []DictionaryItem:
+		return e.encodeDictionaryTo(w, x)
+
+	case Dictionary:
+		return e.encodeDictionaryTo(w, x)
+	}
+
+	var result []byte
+	result, err = e.EncodeAnInterface(ifc)
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(result)
+	return err
+}
+
+// encodeDictionaryTo encodes a 'bencode' dictionary and writes it into the
+// writer.
+func (e Encoder) encodeDictionaryTo(w io.Writer, dictionary []DictionaryItem) (err error) {
+	_, err = w.Write(e.dictionaryPrefix)
+	if err != nil {
+		return err
+	}
+
+	for _, dictItem := range dictionary {
+		err = e.EncodeTo(w, dictItem.Key)
+		if err != nil {
+			return err
+		}
+
+		err = e.EncodeTo(w, dictItem.Value)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = w.Write(e.commonPostfix)
+	return err
+}
+
 // encodeDictionary encodes a 'bencode' dictionary.
 func (e Encoder) encodeDictionary(dictionary []DictionaryItem) (result []byte, err error) {
 
@@ -178,89 +273,64 @@ func (e Encoder) encodeDictionary(dictionary []DictionaryItem) (result []byte, e
 	return e.addPostfixOfDictionary(result), nil
 }
 
-// encodeInterfaceOfInt encodes an int interface as a 'bencode' integer.
-func (e Encoder) encodeInterfaceOfInt(intInterface any) (result []byte, err error) {
-
-	// Convert the type.
-	var intVar int
-	var ok bool
-	intVar, ok = intInterface.(int)
-	if !ok {
-		return nil, errors.New(ErrTypeAssertion)
+// encodeValue encodes a strongly typed value and appends it to the byte array.
+func (e Encoder) encodeValue(dst []byte, value Value) (result []byte, err error) {
+	switch x := value.(type) {
+	case Int:
+		return AppendInteger(dst, int64(x)), nil
+
+	case String:
+		return AppendByteString(dst, x), nil
+
+	case List:
+		result = append(dst, HeaderList)
+		for _, item := range x {
+			result, err = e.encodeValue(result, item)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return append(result, FooterCommon), nil
+
+	case Dict:
+		result = append(dst, HeaderDictionary)
+		for _, entry := range x {
+			result = AppendByteString(result, entry.Key)
+			result, err = e.encodeValue(result, entry.Value)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return append(result, FooterCommon), nil
 	}
 
-	result = e.createTextFromInteger(int64(intVar))
+	// Unknown type.
+	return nil, errors.New(ErrDataType)
+}
 
-	// Add a prefix and a postfix to the integer.
-	return e.addPrefixAndPostfixOfInteger(result), nil
+// encodeInterfaceOfInt encodes an int interface as a 'bencode' integer.
+func (e Encoder) encodeInterfaceOfInt(intInterface any) (result []byte, err error) {
+	return encodeInterfaceOfSignedInteger[int](e, intInterface)
 }
 
 // encodeInterfaceOfInt8 encodes an int8 interface as a 'bencode' integer.
 func (e Encoder) encodeInterfaceOfInt8(int8Interface any) (result []byte, err error) {
-
-	// Convert the type.
-	var int8var int8
-	var ok bool
-	int8var, ok = int8Interface.(int8)
-	if !ok {
-		return nil, errors.New(ErrTypeAssertion)
-	}
-
-	result = e.createTextFromInteger(int64(int8var))
-
-	// Add a prefix and a postfix to the integer.
-	return e.addPrefixAndPostfixOfInteger(result), nil
+	return encodeInterfaceOfSignedInteger[int8](e, int8Interface)
 }
 
 // encodeInterfaceOfInt16 encodes an int16 interface as a 'bencode' integer.
 func (e Encoder) encodeInterfaceOfInt16(int16Interface any) (result []byte, err error) {
-
-	// Convert the type.
-	var int16var int16
-	var ok bool
-	int16var, ok = int16Interface.(int16)
-	if !ok {
-		return nil, errors.New(ErrTypeAssertion)
-	}
-
-	result = e.createTextFromInteger(int64(int16var))
-
-	// Add a prefix and a postfix to the integer.
-	return e.addPrefixAndPostfixOfInteger(result), nil
+	return encodeInterfaceOfSignedInteger[int16](e, int16Interface)
 }
 
 // encodeInterfaceOfInt32 encodes an int32 interface as a 'bencode' integer.
 func (e Encoder) encodeInterfaceOfInt32(int32Interface any) (result []byte, err error) {
-
-	// Convert the type.
-	var int32var int32
-	var ok bool
-	int32var, ok = int32Interface.(int32)
-	if !ok {
-		return nil, errors.New(ErrTypeAssertion)
-	}
-
-	result = e.createTextFromInteger(int64(int32var))
-
-	// Add a prefix and a postfix to the integer.
-	return e.addPrefixAndPostfixOfInteger(result), nil
+	return encodeInterfaceOfSignedInteger[int32](e, int32Interface)
 }
 
 // encodeInterfaceOfInt64 encodes an int64 interface as a 'bencode' integer.
 func (e Encoder) encodeInterfaceOfInt64(int64Interface any) (result []byte, err error) {
-
-	// Convert the type.
-	var int64var int64
-	var ok bool
-	int64var, ok = int64Interface.(int64)
-	if !ok {
-		return nil, errors.New(ErrTypeAssertion)
-	}
-
-	result = e.createTextFromInteger(int64var)
-
-	// Add a prefix and a postfix to the integer.
-	return e.addPrefixAndPostfixOfInteger(result), nil
+	return encodeInterfaceOfSignedInteger[int64](e, int64Interface)
 }
 
 // encodeInterfaceOfList encodes an interface as a 'bencode' list.
@@ -308,6 +378,13 @@ func (e Encoder) encodeInterfaceOfSlice(sliceInterface any) (result []byte, err
 		return e.encodeDictionary(dictionary)
 	}
 
+	// Try to change the type to named dictionary.
+	var namedDictionary Dictionary
+	namedDictionary, ok = sliceInterface.(Dictionary)
+	if ok {
+		return e.encodeDictionary(namedDictionary)
+	}
+
 	// Try to change the type to list.
 	var list []any
 	list, ok = sliceInterface.([]any)
@@ -355,84 +432,60 @@ func (e Encoder) encodeInterfaceOfString(stringInterface any) (result []byte, er
 
 // encodeInterfaceOfUint encodes an uint interface as a 'bencode' integer.
 func (e Encoder) encodeInterfaceOfUint(uintInterface any) (result []byte, err error) {
-
-	// Convert the type.
-	var ok bool
-	var uintVar uint
-	uintVar, ok = uintInterface.(uint)
-	if !ok {
-		return nil, errors.New(ErrTypeAssertion)
-	}
-
-	result = e.createTextFromUInteger(uint64(uintVar))
-
-	// Add a prefix and a postfix to the integer.
-	return e.addPrefixAndPostfixOfInteger(result), nil
+	return encodeInterfaceOfUnsignedInteger[uint](e, uintInterface)
 }
 
 // encodeInterfaceOfUint8 encodes an uint8 interface as a 'bencode' integer.
 func (e Encoder) encodeInterfaceOfUint8(uint8Interface any) (result []byte, err error) {
-
-	// Convert the type.
-	var ok bool
-	var uint8var uint8
-	uint8var, ok = uint8Interface.(uint8)
-	if !ok {
-		return nil, errors.New(ErrTypeAssertion)
-	}
-
-	result = e.createTextFromUInteger(uint64(uint8var))
-
-	// Add a prefix and a postfix to the integer.
-	return e.addPrefixAndPostfixOfInteger(result), nil
+	return encodeInterfaceOfUnsignedInteger[uint8](e, uint8Interface)
 }
 
 // encodeInterfaceOfUint16 encodes an uint16 interface as a 'bencode' integer.
 func (e Encoder) encodeInterfaceOfUint16(uint16Interface any) (result []byte, err error) {
-
-	// Convert the type.
-	var ok bool
-	var uint16var uint16
-	uint16var, ok = uint16Interface.(uint16)
-	if !ok {
-		return nil, errors.New(ErrTypeAssertion)
-	}
-
-	result = e.createTextFromUInteger(uint64(uint16var))
-
-	// Add a prefix and a postfix to the integer.
-	return e.addPrefixAndPostfixOfInteger(result), nil
+	return encodeInterfaceOfUnsignedInteger[uint16](e, uint16Interface)
 }
 
 // encodeInterfaceOfUint32 encodes an uint32 interface as a 'bencode' integer.
 func (e Encoder) encodeInterfaceOfUint32(uint32Interface any) (result []byte, err error) {
+	return encodeInterfaceOfUnsignedInteger[uint32](e, uint32Interface)
+}
+
+// encodeInterfaceOfUint64 encodes an uint64 interface as a 'bencode' integer.
+func (e Encoder) encodeInterfaceOfUint64(uint64Interface any) (result []byte, err error) {
+	return encodeInterfaceOfUnsignedInteger[uint64](e, uint64Interface)
+}
+
+// encodeInterfaceOfSignedInteger encodes an interface holding a signed
+// integer of type T as a 'bencode' integer.
+func encodeInterfaceOfSignedInteger[T int | int8 | int16 | int32 | int64](e Encoder, ifc any) (result []byte, err error) {
 
 	// Convert the type.
+	var value T
 	var ok bool
-	var uint32var uint32
-	uint32var, ok = uint32Interface.(uint32)
+	value, ok = ifc.(T)
 	if !ok {
 		return nil, errors.New(ErrTypeAssertion)
 	}
 
-	result = e.createTextFromUInteger(uint64(uint32var))
+	result = e.createTextFromInteger(int64(value))
 
 	// Add a prefix and a postfix to the integer.
 	return e.addPrefixAndPostfixOfInteger(result), nil
 }
 
-// encodeInterfaceOfUint64 encodes an uint64 interface as a 'bencode' integer.
-func (e Encoder) encodeInterfaceOfUint64(uint64Interface any) (result []byte, err error) {
+// encodeInterfaceOfUnsignedInteger encodes an interface holding an unsigned
+// integer of type T as a 'bencode' integer.
+func encodeInterfaceOfUnsignedInteger[T uint | uint8 | uint16 | uint32 | uint64](e Encoder, ifc any) (result []byte, err error) {
 
 	// Convert the type.
+	var value T
 	var ok bool
-	var uint64var uint64
-	uint64var, ok = uint64Interface.(uint64)
+	value, ok = ifc.(T)
 	if !ok {
 		return nil, errors.New(ErrTypeAssertion)
 	}
 
-	result = e.createTextFromUInteger(uint64var)
+	result = e.createTextFromUInteger(uint64(value))
 
 	// Add a prefix and a postfix to the integer.
 	return e.addPrefixAndPostfixOfInteger(result), nil
@@ -1,6 +1,7 @@
 package bencode
 
 import (
+	"bytes"
 	"fmt"
 	"testing"
 	"time"
@@ -220,6 +221,42 @@ func Test_Encoder_EncodeAnInterface(t *testing.T) {
 	}
 }
 
+func Test_Encoder_EncodeTo(t *testing.T) {
+	var aTest = tester.New(t)
+	var encoder = NewEncoder()
+
+	// Test #1. Positive.
+	var values = []any{
+		int64(-5),
+		uint8(7),
+		[]byte("abc"),
+		"xyz",
+		[]any{int64(1), []byte{}, []any{}},
+		[]DictionaryItem{
+			{Key: []byte("b"), Value: "x"},
+			{Key: []byte("a"), Value: []any{int64(2)}},
+		},
+		Dictionary{{Key: []byte("k"), Value: Dictionary{}}},
+		List{Int(1), String("s")},
+	}
+	for _, value := range values {
+		var buffer bytes.Buffer
+		err := encoder.EncodeTo(&buffer, value)
+		aTest.MustBeNoError(err)
+
+		expected, err := encoder.EncodeAnInterface(value)
+		aTest.MustBeNoError(err)
+		aTest.MustBeEqual(buffer.Bytes(), expected)
+	}
+
+	// Test #2. Negative.
+	{
+		var buffer bytes.Buffer
+		err := encoder.EncodeTo(&buffer, []any{int64(1), time.Time{}})
+		aTest.MustBeAnError(err)
+	}
+}
+
 func Test_Encoder_encodeDictionary(t *testing.T) {
 
 	var aTest = tester.New(t)
@@ -2,8 +2,12 @@ package bencode
 
 import (
 	"bufio"
+	"bytes"
+	"crypto/sha256"
 	"errors"
+	"fmt"
 	"io"
+	"io/fs"
 	"os"
 	"time"
 
@@ -12,8 +16,9 @@ import (
 
 // File is a file.
 type File struct {
-	path   string
-	osFile *os.File
+	path    string
+	osFile  *os.File
+	mapping []byte
 }
 
 // NewFile is a file's constructor.
@@ -30,6 +35,12 @@ func (f *File) close() (err error) {
 	return f.osFile.Close()
 }
 
+// Close releases the memory mapping of the file created by ParseMapped.
+// Objects decoded from the mapping must not be used after closing.
+func (f *File) Close() (err error) {
+	return f.unmap()
+}
+
 // getContents reads the contents of an opened file.
 func (f *File) getContents() (fileContents []byte, err error) {
 
@@ -38,11 +49,6 @@ func (f *File) getContents() (fileContents []byte, err error) {
 		return nil, errors.New(ErrFileNotInitialized)
 	}
 
-	_, err = f.osFile.Seek(0, 0)
-	if err != nil {
-		return nil, err
-	}
-
 	fileContents, err = io.ReadAll(f.osFile)
 	if err != nil {
 		return nil, err
@@ -61,13 +67,37 @@ func (f *File) open() (err error) {
 	return nil
 }
 
+// ParseOptions are the options of parsing.
+//
+// If 'MakeSelfCheck' flag is enabled, the self check is performed after
+// decoding, as MakeSelfCheck does; the order of keys is not checked, see
+// MakeSelfCheckReport. If 'DiscardSourceData' flag is enabled, the decoded object does
+// not keep the source data; its hash sum and size are kept anyway.
+// Compressed data is decompressed before decoding, its size is limited by
+// 'MaxDecompressedSize', or by the default limit when it is not set.
+type ParseOptions struct {
+	MakeSelfCheck       bool
+	DiscardSourceData   bool
+	MaxDecompressedSize int64
+}
+
 // Parse parses an input file into an interface. It also stores some
 // additional data, all packed into an object.
 // If 'makeSelfCheck' flag is enabled, the self check is performed after
 // decoding.
+// Files compressed with gzip, zlib or bzip2 are decompressed transparently.
+// The file is read only once and the data is decoded from memory, so that
+// the source data of the object is exactly the data which was decoded; data
+// after the decoded value is an error.
 func (f *File) Parse(makeSelfCheck bool) (result *DecodedObject, err error) {
+	return f.ParseWithOptions(ParseOptions{MakeSelfCheck: makeSelfCheck})
+}
 
-	// Open the file and prepare a stream reader.
+// ParseWithOptions parses an input file into an object using the options.
+// See Parse for details.
+func (f *File) ParseWithOptions(options ParseOptions) (result *DecodedObject, err error) {
+
+	// Open the file.
 	err = f.open()
 	if err != nil {
 		return nil, err
@@ -81,12 +111,8 @@ func (f *File) Parse(makeSelfCheck bool) (result *DecodedObject, err error) {
 		}
 	}()
 
-	var bufioReader = bufio.NewReader(f.osFile)
-
-	// Parse the file encoded with 'bencode' encoding into an object.
-	var decoder = NewDecoder(bufioReader)
-	var ifc any
-	ifc, err = decoder.readBencodedValue()
+	var fileInfo os.FileInfo
+	fileInfo, err = f.osFile.Stat()
 	if err != nil {
 		return nil, err
 	}
@@ -98,23 +124,150 @@ func (f *File) Parse(makeSelfCheck bool) (result *DecodedObject, err error) {
 		return nil, err
 	}
 
+	result, err = parseSourceData(f.path, fileContents, options)
+	if err != nil {
+		return nil, err
+	}
+
+	result.FileModTime = fileInfo.ModTime()
+
+	return result, nil
+}
+
+// ParseReader parses all the data read from the reader into an object. The
+// file path of the object is empty. If 'makeSelfCheck' flag is enabled, the
+// self check is performed after decoding.
+func ParseReader(r io.Reader, makeSelfCheck bool) (result *DecodedObject, err error) {
+	return ParseReaderWithOptions(r, ParseOptions{MakeSelfCheck: makeSelfCheck})
+}
+
+// ParseReaderWithOptions parses all the data read from the reader into an
+// object using the options.
+func ParseReaderWithOptions(r io.Reader, options ParseOptions) (result *DecodedObject, err error) {
+	var data []byte
+	data, err = io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseSourceData("", data, options)
+}
+
+// ParseFS parses the named file of the file system into an object, e.g. a
+// file embedded into a program, a file of a ZIP archive or a test fixture.
+// The name is stored as the FSName of the object, its file path is not set.
+// If 'makeSelfCheck' flag is enabled, the self check is performed after
+// decoding.
+func ParseFS(fsys fs.FS, name string, makeSelfCheck bool) (result *DecodedObject, err error) {
+	return ParseFSWithOptions(fsys, name, ParseOptions{MakeSelfCheck: makeSelfCheck})
+}
+
+// ParseFSWithOptions parses the named file of the file system into an object
+// using the options.
+func ParseFSWithOptions(fsys fs.FS, name string, options ParseOptions) (result *DecodedObject, err error) {
+	var data []byte
+	data, err = fs.ReadFile(fsys, name)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err = parseSourceData("", data, options)
+	if err != nil {
+		return nil, err
+	}
+
+	result.FSName = name
+
+	// Modification time is optional for file systems.
+	var fileInfo fs.FileInfo
+	fileInfo, err = fs.Stat(fsys, name)
+	if err == nil {
+		result.FileModTime = fileInfo.ModTime()
+	}
+
+	return result, nil
+}
+
+// parseSourceData parses the data encoded with 'bencode' encoding into an
+// object. Compressed data is decompressed first. The object keeps the
+// decoded data as its source data, trailing data is an error.
+func parseSourceData(filePath string, data []byte, options ParseOptions) (result *DecodedObject, err error) {
+	// Decompress the data if needed.
+	var dataSize = int64(len(data))
+	var compression Compression
+	data, compression, err = decompressData(data, options.MaxDecompressedSize)
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse the data into an object.
+	var decodeStartTime = time.Now()
+	var bytesReader = bytes.NewReader(data)
+	var bufioReader = bufio.NewReader(bytesReader)
+	var ifc any
+	ifc, err = NewDecoder(bufioReader).readBencodedValue()
+	if err != nil {
+		return nil, err
+	}
+
+	// Check for the trailing data.
+	err = checkTrailingData(len(data), bufioReader.Buffered()+bytesReader.Len(), options)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err = newDecodedObject(filePath, data, ifc, decodeStartTime, options)
+	if err != nil {
+		return nil, err
+	}
+
+	result.FileSize = dataSize
+	result.Compression = compression
+
+	return result, nil
+}
+
+// checkTrailingData checks that the data ends with the decoded value, so that
+// the source data of an object is exactly the decoded data. The self-check
+// explains trailing data in its report, so the error is left to it when it
+// is enabled.
+func checkTrailingData(dataSize int, bytesLeft int, options ParseOptions) (err error) {
+	if (bytesLeft > 0) && !options.MakeSelfCheck {
+		return fmt.Errorf(ErrFTrailingData, dataSize-bytesLeft)
+	}
+
+	return nil
+}
+
+// newDecodedObject packs the decoded data into an object, collects its
+// meta-data and performs a self-check if needed.
+func newDecodedObject(filePath string, data []byte, ifc any, decodeStartTime time.Time, options ParseOptions) (result *DecodedObject, err error) {
+
 	// Prepare the result.
 	var decodedObject *DecodedObject
 	decodedObject = &DecodedObject{
-		FilePath:        f.path,
-		SourceData:      fileContents,
+		FilePath:        filePath,
+		SourceData:      data,
 		RawObject:       ifc,
-		DecodeTimestamp: time.Now().Unix(),
+		DecodeTimestamp: time.Now(),
+		FileSize:        int64(len(data)),
+		SourceSHA256:    sha256.Sum256(data),
+		Statistics:      collectStatistics(ifc),
 	}
+	decodedObject.DecodeDuration = decodedObject.DecodeTimestamp.Sub(decodeStartTime)
 
 	// Perform a self-check if needed.
-	if makeSelfCheck {
-		ok := decodedObject.MakeSelfCheck()
-		if !ok {
-			return nil, errors.New(ErrSelfCheck)
+	if options.MakeSelfCheck {
+		report := decodedObject.checkEncoding()
+		if report != nil {
+			return nil, &SelfCheckError{Report: report}
 		}
 	}
 
+	if options.DiscardSourceData {
+		decodedObject.SourceData = nil
+	}
+
 	return decodedObject, nil
 }
 
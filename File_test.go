@@ -1,10 +1,13 @@
 package bencode
 
 import (
+	"crypto/sha256"
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"testing/fstest"
 
 	"github.com/vault-thirteen/auxie/tester"
 )
@@ -195,7 +198,17 @@ func Test_File_Parse(t *testing.T) {
 			},
 			DecodeTimestamp: do.DecodeTimestamp, // Synchronization with Test.
 			//
-			IsSelfChecked: false,
+			IsSelfChecked:  false,
+			FileSize:       int64(len(TestFileBContents)),
+			FileModTime:    do.FileModTime, // Synchronization with Test.
+			SourceSHA256:   sha256.Sum256([]byte(TestFileBContents)),
+			DecodeDuration: do.DecodeDuration, // Synchronization with Test.
+			Statistics: Statistics{
+				ByteStrings:       1,
+				Dictionaries:      1,
+				MaxDepth:          1,
+				LargestByteString: 3,
+			},
 		}
 		aTest.MustBeEqual(do, doExpected)
 	}
@@ -219,12 +232,83 @@ func Test_File_Parse(t *testing.T) {
 			},
 			DecodeTimestamp: do.DecodeTimestamp, // Synchronization with Test.
 			//
-			IsSelfChecked: true,
+			IsSelfChecked:  true,
+			FileSize:       int64(len(TestFileBContents)),
+			FileModTime:    do.FileModTime, // Synchronization with Test.
+			SourceSHA256:   sha256.Sum256([]byte(TestFileBContents)),
+			DecodeDuration: do.DecodeDuration, // Synchronization with Test.
+			Statistics: Statistics{
+				ByteStrings:       1,
+				Dictionaries:      1,
+				MaxDepth:          1,
+				LargestByteString: 3,
+			},
 		}
 		aTest.MustBeEqual(do, doExpected)
 	}
 }
 
+func Test_ParseReader(t *testing.T) {
+	var aTest = tester.New(t)
+
+	// Test #1. Positive.
+	{
+		do, err := ParseReader(strings.NewReader(TestFileBContents), true)
+		aTest.MustBeNoError(err)
+		aTest.MustBeEqual(do.FilePath, "")
+		aTest.MustBeEqual(do.SourceData, []byte(TestFileBContents))
+		aTest.MustBeEqual(do.IsSelfChecked, true)
+	}
+
+	// Test #2. Negative.
+	{
+		_, err := ParseReader(strings.NewReader("d4:info"), false)
+		aTest.MustBeAnError(err)
+	}
+}
+
+func Test_ParseFS(t *testing.T) {
+	var aTest = tester.New(t)
+
+	var fsys = fstest.MapFS{
+		"data/b.torrent": &fstest.MapFile{Data: []byte(TestFileBContents)},
+		"data/c.torrent": &fstest.MapFile{Data: []byte("i1ei2e")},
+	}
+
+	// Test #1. Positive.
+	{
+		do, err := ParseFS(fsys, "data/b.torrent", true)
+		aTest.MustBeNoError(err)
+		aTest.MustBeEqual(do.FilePath, "")
+		aTest.MustBeEqual(do.FSName, "data/b.torrent")
+		aTest.MustBeEqual(do.RawObject, []DictionaryItem{
+			{
+				Key:      []byte("info"),
+				Value:    []byte("Sun"),
+				KeyStr:   "info",
+				ValueStr: "Sun",
+			},
+		})
+		aTest.MustBeEqual(do.IsSelfChecked, true)
+
+		// The object is not written into the working folder.
+		err = do.WriteFile(false)
+		aTest.MustBeAnError(err)
+	}
+
+	// Test #2. Negative: self-check fails.
+	{
+		_, err := ParseFS(fsys, "data/c.torrent", true)
+		aTest.MustBeAnError(err)
+	}
+
+	// Test #3. Negative: no file.
+	{
+		_, err := ParseFS(fsys, "data/x.torrent", false)
+		aTest.MustBeAnError(err)
+	}
+}
+
 func Test_File_GetPath(t *testing.T) {
 	var aTest = tester.New(t)
 
@@ -232,3 +316,33 @@ func Test_File_GetPath(t *testing.T) {
 	var f = NewFile("the_path")
 	aTest.MustBeEqual(f.GetPath(), "the_path")
 }
+
+func Test_parseSourceData(t *testing.T) {
+	var aTest = tester.New(t)
+
+	// Test #1. Positive.
+	{
+		var data = []byte("d4:info3:Sune")
+		do, err := parseSourceData("path", data, ParseOptions{MakeSelfCheck: true})
+		aTest.MustBeNoError(err)
+		aTest.MustBeEqual(do.FilePath, "path")
+		aTest.MustBeEqual(&do.SourceData[0], &data[0])
+		aTest.MustBeEqual(do.IsSelfChecked, true)
+	}
+
+	// Test #2. Negative: syntax error.
+	{
+		_, err := parseSourceData("path", []byte("d4:info"), ParseOptions{})
+		aTest.MustBeAnError(err)
+	}
+
+	// Test #3. Negative: trailing data.
+	{
+		_, err := parseSourceData("path", []byte("i1ei2e"), ParseOptions{MakeSelfCheck: true})
+		aTest.MustBeAnError(err)
+
+		_, err = parseSourceData("path", []byte("i1ei2e"), ParseOptions{})
+		aTest.MustBeAnError(err)
+		aTest.MustBeEqual(err.Error(), "trailing data at offset: 3")
+	}
+}
@@ -0,0 +1,166 @@
+package bencode
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/base32"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// InfoKey is the key of the info dictionary of a torrent file.
+const InfoKey = "info"
+
+// InfoHash is the version 1 info hash of a torrent, i.e. the SHA-1 hash sum
+// of its 'bencoded' info dictionary.
+type InfoHash [sha1.Size]byte
+
+// Lengths of the text forms of an info hash.
+const (
+	InfoHashHexLength    = sha1.Size * 2
+	InfoHashBase32Length = sha1.Size * 8 / 5
+)
+
+// ParseInfoHash parses an info hash written in hexadecimal form, as 40
+// symbols, or in base32 form, as 32 symbols. Both forms are
+// case-insensitive.
+func ParseInfoHash(s string) (h InfoHash, err error) {
+	switch len(s) {
+	case InfoHashHexLength:
+		_, err = hex.Decode(h[:], []byte(s))
+
+	case InfoHashBase32Length:
+		_, err = base32.StdEncoding.Decode(h[:], []byte(strings.ToUpper(s)))
+
+	default:
+		err = errors.New(ErrInfoHashLength)
+	}
+
+	if err != nil {
+		return InfoHash{}, fmt.Errorf(ErrFInfoHash, s, err)
+	}
+
+	return h, nil
+}
+
+// String returns the info hash in hexadecimal form.
+func (h InfoHash) String() string {
+	return h.Hex()
+}
+
+// Hex returns the info hash in lower-case hexadecimal form.
+func (h InfoHash) Hex() string {
+	return hex.EncodeToString(h[:])
+}
+
+// Base32 returns the info hash in base32 form, as used in old magnet links.
+func (h InfoHash) Base32() string {
+	return base32.StdEncoding.EncodeToString(h[:])
+}
+
+// InfoHashV1 calculates the version 1 info hash of a 'bencoded' torrent
+// file. The hash sum is calculated over the exact bytes of the value of the
+// top-level 'info' key, the value is not re-encoded, so that the hash sum is
+// right even for files which are not encoded canonically, e.g. with unsorted
+// keys. The data must contain exactly one dictionary with a single 'info'
+// key.
+func InfoHashV1(data []byte) (h InfoHash, err error) {
+	var start, end int
+	start, end, err = findTopLevelValue(data, InfoKey)
+	if err != nil {
+		return InfoHash{}, err
+	}
+
+	return sha1.Sum(data[start:end]), nil
+}
+
+// InfoHashV1 calculates the version 1 info hash of the source data. See the
+// InfoHashV1 function for details.
+func (do *DecodedObject) InfoHashV1() (h InfoHash, err error) {
+
+	// Fool check.
+	if do.SourceData == nil {
+		return InfoHash{}, errors.New(ErrSourceDataIsNotSet)
+	}
+
+	return InfoHashV1(do.SourceData)
+}
+
+// findTopLevelValue finds the position of the value of the key in the
+// top-level dictionary. The whole data is checked, the key must be unique.
+func findTopLevelValue(data []byte, key string) (start int, end int, err error) {
+	var src = newSliceSource(data)
+
+	var b byte
+	b, err = src.ReadByte()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if b != HeaderDictionary {
+		return 0, 0, fmt.Errorf(ErrFKindMismatch, KindDict, headerKind(b))
+	}
+
+	var isFound = false
+	for {
+		b, err = src.ReadByte()
+		if err != nil {
+			return 0, 0, err
+		}
+
+		if b == FooterCommon {
+			break
+		}
+
+		src.pos--
+		var dictKey []byte
+		dictKey, err = readByteString(src)
+		if err != nil {
+			return 0, 0, err
+		}
+
+		var valueStart = src.pos
+		err = skipBencodedValue(src)
+		if err != nil {
+			return 0, 0, err
+		}
+
+		if !bytes.Equal(dictKey, []byte(key)) {
+			continue
+		}
+
+		if isFound {
+			return 0, 0, fmt.Errorf(ErrFKeyIsDuplicate, key)
+		}
+
+		start, end, isFound = valueStart, src.pos, true
+	}
+
+	if src.pos < len(data) {
+		return 0, 0, fmt.Errorf(ErrFTrailingData, src.pos)
+	}
+
+	if !isFound {
+		return 0, 0, fmt.Errorf(ErrFKeyIsNotFound, key)
+	}
+
+	return start, end, nil
+}
+
+// headerKind returns the kind of a value by its first byte.
+func headerKind(b byte) Kind {
+	switch {
+	case b == HeaderDictionary:
+		return KindDict
+	case b == HeaderList:
+		return KindList
+	case b == HeaderInteger:
+		return KindInt
+	case isByteNonNegativeAsciiNumeric(b):
+		return KindString
+	}
+
+	return KindInvalid
+}
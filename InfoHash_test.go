@@ -0,0 +1,125 @@
+package bencode
+
+import (
+	"crypto/sha1"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/vault-thirteen/auxie/tester"
+)
+
+// Test Settings.
+const (
+	TestTorrentInfoHashHex    = "9ddf6a9b17b624991b39f8afd2edc64f673350e3"
+	TestTorrentInfoHashBase32 = "TXPWVGYXWYSJSGZZ7CX5F3OGJ5TTGUHD"
+)
+
+func Test_InfoHashV1(t *testing.T) {
+	var aTest = tester.New(t)
+
+	// Test #1. Positive: a real torrent file.
+	{
+		data, err := os.ReadFile(filepath.Join("example", "data", "5942384.torrent"))
+		aTest.MustBeNoError(err)
+
+		h, err := InfoHashV1(data)
+		aTest.MustBeNoError(err)
+		aTest.MustBeEqual(h.Hex(), TestTorrentInfoHashHex)
+	}
+
+	// Test #2. Positive: unsorted keys are hashed as they are.
+	{
+		h, err := InfoHashV1([]byte("d4:infod4:name1:a6:lengthi1ee8:announce1:xe"))
+		aTest.MustBeNoError(err)
+		aTest.MustBeEqual(h, InfoHash(sha1.Sum([]byte("d4:name1:a6:lengthi1ee"))))
+	}
+
+	// Test #3. Negative.
+	{
+		var inputs = []string{
+			"",
+			"li1ee",
+			"d8:announce1:xe",
+			"d4:infodee4:infodee",
+			"d4:infodeei1e",
+			"d4:infod4:name",
+			"d4:infodxee",
+		}
+		for _, input := range inputs {
+			_, err := InfoHashV1([]byte(input))
+			aTest.MustBeAnError(err)
+		}
+	}
+}
+
+func Test_DecodedObject_InfoHashV1(t *testing.T) {
+	var aTest = tester.New(t)
+
+	var f = NewFile(filepath.Join("example", "data", "5942384.torrent"))
+
+	// Test #1. Positive.
+	{
+		do, err := f.Parse(false)
+		aTest.MustBeNoError(err)
+
+		h, err := do.InfoHashV1()
+		aTest.MustBeNoError(err)
+		aTest.MustBeEqual(h.String(), TestTorrentInfoHashHex)
+	}
+
+	// Test #2. Negative: no source data.
+	{
+		do, err := f.ParseWithOptions(ParseOptions{DiscardSourceData: true})
+		aTest.MustBeNoError(err)
+
+		_, err = do.InfoHashV1()
+		aTest.MustBeAnError(err)
+	}
+}
+
+func Test_ParseInfoHash(t *testing.T) {
+	var aTest = tester.New(t)
+
+	// Test #1. Positive.
+	{
+		h, err := ParseInfoHash(TestTorrentInfoHashHex)
+		aTest.MustBeNoError(err)
+		aTest.MustBeEqual(h.Hex(), TestTorrentInfoHashHex)
+		aTest.MustBeEqual(h.Base32(), TestTorrentInfoHashBase32)
+
+		h, err = ParseInfoHash(strings.ToUpper(TestTorrentInfoHashHex))
+		aTest.MustBeNoError(err)
+		aTest.MustBeEqual(h.Hex(), TestTorrentInfoHashHex)
+
+		h, err = ParseInfoHash(strings.ToLower(TestTorrentInfoHashBase32))
+		aTest.MustBeNoError(err)
+		aTest.MustBeEqual(h.Hex(), TestTorrentInfoHashHex)
+	}
+
+	// Test #2. Negative.
+	{
+		var inputs = []string{
+			"",
+			TestTorrentInfoHashHex[1:],
+			"x" + TestTorrentInfoHashHex[1:],
+			"1" + TestTorrentInfoHashBase32[1:],
+		}
+		for _, input := range inputs {
+			h, err := ParseInfoHash(input)
+			aTest.MustBeAnError(err)
+			aTest.MustBeEqual(h, InfoHash{})
+		}
+	}
+}
+
+func Test_headerKind(t *testing.T) {
+	var aTest = tester.New(t)
+
+	aTest.MustBeEqual(headerKind('d'), KindDict)
+	aTest.MustBeEqual(headerKind('l'), KindList)
+	aTest.MustBeEqual(headerKind('i'), KindInt)
+	aTest.MustBeEqual(headerKind('7'), KindString)
+	aTest.MustBeEqual(headerKind('x'), KindInvalid)
+}
@@ -0,0 +1,232 @@
+package bencode
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// Range is an inclusive range of integers.
+type Range struct {
+	Min int64
+	Max int64
+}
+
+// contains checks whether the range contains the number.
+func (r Range) contains(n int64) bool {
+	return (n >= r.Min) && (n <= r.Max)
+}
+
+// Schema describes the expected shape of a value. Empty fields of a schema
+// do not restrict anything, e.g. an empty schema accepts any value.
+type Schema struct {
+	// Kind of the value. KindInvalid accepts any kind.
+	Kind Kind
+
+	// Range of an integer.
+	IntRange *Range
+
+	// Range of the length of a byte string.
+	Length *Range
+
+	// If set, the length of a byte string must be a multiple of this number,
+	// e.g. 20 for the 'pieces' field of a torrent.
+	LengthMultipleOf int64
+
+	// If set, a byte string must be a valid UTF-8 text.
+	Text bool
+
+	// Schema of all the elements of a list.
+	Items *Schema
+
+	// Range of the number of elements of a list or items of a dictionary.
+	Count *Range
+
+	// Known keys of a dictionary.
+	Fields []Field
+
+	// Schema of the keys of a dictionary which are not listed in Fields. If
+	// it is not set and Fields are listed, such keys are an error unless
+	// AllowUnknownKeys is set.
+	Keys *Schema
+
+	// Schema of the values of the keys which are not listed in Fields.
+	Values *Schema
+
+	// If set, keys which are not listed in Fields are accepted.
+	AllowUnknownKeys bool
+
+	// If set, keys of a dictionary must be sorted as required by the
+	// specification. Duplicate keys are always an error.
+	SortedKeys bool
+
+	// Alternative schemas, the value must match at least one of them, e.g.
+	// a single-file or a multi-file torrent.
+	OneOf []*Schema
+}
+
+// Field is a known key of a dictionary.
+type Field struct {
+	Key      string
+	Required bool
+	Schema   *Schema
+}
+
+// ValidationError is a problem found during the validation.
+type ValidationError struct {
+	Path    string
+	Message string
+}
+
+// Error returns the text of the problem.
+func (ve ValidationError) Error() string {
+	if len(ve.Path) == 0 {
+		return "(root): " + ve.Message
+	}
+
+	return ve.Path + ": " + ve.Message
+}
+
+// ValidationErrors is a list of all the problems found during the validation.
+type ValidationErrors []ValidationError
+
+// Error returns the texts of all the problems.
+func (ves ValidationErrors) Error() string {
+	var messages = make([]string, 0, len(ves))
+	for _, ve := range ves {
+		messages = append(messages, ve.Error())
+	}
+
+	return strings.Join(messages, "; ")
+}
+
+// Validate checks the value against the schema. The value may be given in any
+// form accepted by ToValue. All the problems are reported, each one with the
+// key path of the wrong node; the returned error is of the ValidationErrors
+// type.
+func Validate(v any, schema *Schema) (err error) {
+	var value Value
+	value, err = ToValue(v)
+	if err != nil {
+		return ValidationErrors{{Message: err.Error()}}
+	}
+
+	var problems = validateValue(nil, "", value, schema)
+	if len(problems) > 0 {
+		return problems
+	}
+
+	return nil
+}
+
+// validateValue appends the problems of a value to the list.
+func validateValue(problems ValidationErrors, path string, v Value, schema *Schema) ValidationErrors {
+	if schema == nil {
+		return problems
+	}
+
+	var report = func(format string, args ...any) {
+		problems = append(problems, ValidationError{Path: path, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if len(schema.OneOf) > 0 {
+		if !matchesOneOf(path, v, schema.OneOf) {
+			report(ErrSchemaAlternatives)
+		}
+	}
+
+	if (schema.Kind != KindInvalid) && (valueKind(v) != schema.Kind) {
+		report(ErrFKindMismatch, schema.Kind, valueKind(v))
+		return problems
+	}
+
+	switch x := v.(type) {
+	case Int:
+		if (schema.IntRange != nil) && !schema.IntRange.contains(int64(x)) {
+			report(ErrFSchemaIntegerRange, schema.IntRange.Min, schema.IntRange.Max, int64(x))
+		}
+
+	case String:
+		if (schema.Length != nil) && !schema.Length.contains(int64(len(x))) {
+			report(ErrFSchemaLengthRange, schema.Length.Min, schema.Length.Max, len(x))
+		}
+		if (schema.LengthMultipleOf > 0) && (int64(len(x))%schema.LengthMultipleOf != 0) {
+			report(ErrFSchemaLengthMultiple, schema.LengthMultipleOf, len(x))
+		}
+		if schema.Text && !utf8.Valid(x) {
+			report(ErrSchemaText)
+		}
+
+	case List:
+		if (schema.Count != nil) && !schema.Count.contains(int64(len(x))) {
+			report(ErrFSchemaCountRange, schema.Count.Min, schema.Count.Max, len(x))
+		}
+		for i, item := range x {
+			problems = validateValue(problems, appendPathIndex(path, i), item, schema.Items)
+		}
+
+	case Dict:
+		if (schema.Count != nil) && !schema.Count.contains(int64(len(x))) {
+			report(ErrFSchemaCountRange, schema.Count.Min, schema.Count.Max, len(x))
+		}
+		problems = validateDict(problems, path, x, schema)
+	}
+
+	return problems
+}
+
+// validateDict appends the problems of dictionary items to the list.
+func validateDict(problems ValidationErrors, path string, dict Dict, schema *Schema) ValidationErrors {
+	var fields = make(map[string]*Field, len(schema.Fields))
+	for i := range schema.Fields {
+		fields[schema.Fields[i].Key] = &schema.Fields[i]
+	}
+
+	var seenKeys = make(map[string]bool, len(dict))
+	for i, entry := range dict {
+		var entryPath = appendPathKey(path, entry.Key)
+
+		if seenKeys[string(entry.Key)] {
+			problems = append(problems, ValidationError{Path: entryPath, Message: ErrSchemaDuplicateKey})
+			continue
+		}
+		seenKeys[string(entry.Key)] = true
+
+		if schema.SortedKeys && (i > 0) && (string(dict[i-1].Key) > string(entry.Key)) {
+			problems = append(problems, ValidationError{Path: entryPath, Message: ErrSchemaUnsortedKey})
+		}
+
+		var field, isKnown = fields[string(entry.Key)]
+		if isKnown {
+			problems = validateValue(problems, entryPath, entry.Value, field.Schema)
+			continue
+		}
+
+		if (len(schema.Fields) > 0) && (schema.Keys == nil) && (schema.Values == nil) && !schema.AllowUnknownKeys {
+			problems = append(problems, ValidationError{Path: entryPath, Message: ErrSchemaUnknownKey})
+			continue
+		}
+
+		problems = validateValue(problems, entryPath, String(entry.Key), schema.Keys)
+		problems = validateValue(problems, entryPath, entry.Value, schema.Values)
+	}
+
+	for _, field := range schema.Fields {
+		if field.Required && !seenKeys[field.Key] {
+			problems = append(problems, ValidationError{Path: path, Message: fmt.Sprintf(ErrFSchemaMissingKey, field.Key)})
+		}
+	}
+
+	return problems
+}
+
+// matchesOneOf checks whether the value matches at least one of the schemas.
+func matchesOneOf(path string, v Value, schemas []*Schema) bool {
+	for _, schema := range schemas {
+		if len(validateValue(nil, path, v, schema)) == 0 {
+			return true
+		}
+	}
+
+	return false
+}
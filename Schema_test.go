@@ -0,0 +1,147 @@
+package bencode
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/vault-thirteen/auxie/tester"
+)
+
+// newTestTorrentSchema creates a schema of a torrent file for tests.
+func newTestTorrentSchema() *Schema {
+	var text = &Schema{Kind: KindString, Text: true}
+	var positive = &Schema{Kind: KindInt, IntRange: &Range{Min: 1, Max: math.MaxInt64}}
+
+	var fileSchema = &Schema{
+		Kind: KindDict,
+		Fields: []Field{
+			{Key: "length", Required: true, Schema: positive},
+			{Key: "path", Required: true, Schema: &Schema{Kind: KindList, Items: text, Count: &Range{Min: 1, Max: math.MaxInt64}}},
+		},
+	}
+
+	var infoSchema = &Schema{
+		Kind: KindDict,
+		OneOf: []*Schema{
+			{Fields: []Field{{Key: "length", Required: true}}, AllowUnknownKeys: true},
+			{Fields: []Field{{Key: "files", Required: true}}, AllowUnknownKeys: true},
+		},
+		Fields: []Field{
+			{Key: "name", Required: true, Schema: text},
+			{Key: "piece length", Required: true, Schema: positive},
+			{Key: "pieces", Required: true, Schema: &Schema{Kind: KindString, LengthMultipleOf: 20}},
+			{Key: "length", Schema: positive},
+			{Key: "files", Schema: &Schema{Kind: KindList, Items: fileSchema}},
+		},
+		AllowUnknownKeys: true,
+		SortedKeys:       true,
+	}
+
+	return &Schema{
+		Kind: KindDict,
+		Fields: []Field{
+			{Key: "announce", Schema: text},
+			{Key: "info", Required: true, Schema: infoSchema},
+		},
+		AllowUnknownKeys: true,
+	}
+}
+
+func Test_Validate(t *testing.T) {
+	var aTest = tester.New(t)
+
+	var schema = newTestTorrentSchema()
+
+	// Test #1. Valid.
+	{
+		var value, err = ParseText([]byte(`{
+			"announce": "http://tracker"
+			"info": {
+				"files": [{"length": 1, "path": ["a"]}]
+				"name": "x"
+				"piece length": 16384
+				"pieces": #0000000000000000000000000000000000000000
+			}
+		}`))
+		aTest.MustBeNoError(err)
+		aTest.MustBeNoError(Validate(value, schema))
+	}
+
+	// Test #2. Invalid.
+	{
+		var value, err = ParseText([]byte(`{
+			"announce": #ff
+			"info": {
+				"piece length": 0
+				"name": "x"
+				"pieces": "abc"
+				"files": [{"length": 1, "path": []}, 5]
+				"name": "y"
+			}
+		}`))
+		aTest.MustBeNoError(err)
+
+		err = Validate(value, schema)
+		aTest.MustBeEqual(err, error(ValidationErrors{
+			{Path: "announce", Message: "the byte string is not a UTF-8 text"},
+			{Path: "info", Message: "the value does not match any of the alternatives"},
+			{Path: "info.piece length", Message: "the integer is out of range [1, 9223372036854775807]: 0"},
+			{Path: "info.name", Message: "the key is not in sorted order"},
+			{Path: "info.pieces", Message: "the length is not a multiple of 20: 3"},
+			{Path: "info.files", Message: "the key is not in sorted order"},
+			{Path: "info.files[0].path", Message: "the number of items is out of range [1, 9223372036854775807]: 0"},
+			{Path: "info.files[1]", Message: "kind mismatch: dictionary is expected, integer is received"},
+			{Path: "info.name", Message: "duplicate key"},
+		}))
+	}
+
+	// Test #3. Missing keys and alternatives.
+	{
+		var value, err = ParseText([]byte(`{"info": {"name": "x"}, "extra": 1}`))
+		aTest.MustBeNoError(err)
+
+		err = Validate(value, schema)
+		aTest.MustBeEqual(err.Error(),
+			"info: the value does not match any of the alternatives; "+
+				"info: required key is missing: piece length; "+
+				"info: required key is missing: pieces",
+		)
+	}
+
+	// Test #4. Unknown keys, key and value schemas.
+	{
+		var scrapeSchema = &Schema{
+			Kind:   KindDict,
+			Keys:   &Schema{Length: &Range{Min: 20, Max: 20}},
+			Values: &Schema{Kind: KindDict},
+		}
+
+		var err = Validate(Dict{{Key: []byte("short"), Value: Int(1)}}, scrapeSchema)
+		aTest.MustBeEqual(err.Error(),
+			"short: the length is out of range [20, 20]: 5; "+
+				"short: kind mismatch: dictionary is expected, integer is received",
+		)
+
+		err = Validate(Dict{{Key: []byte("a"), Value: Int(1)}}, &Schema{Count: &Range{Min: 2, Max: 3}})
+		aTest.MustBeEqual(err.Error(), "(root): the number of items is out of range [2, 3]: 1")
+
+		// An empty schema accepts any dictionary.
+		err = Validate(Dict{{Key: []byte("a"), Value: Int(1)}}, &Schema{})
+		aTest.MustBeNoError(err)
+		err = Validate(Dict{{Key: []byte("a"), Value: Int(1)}}, &Schema{Kind: KindDict})
+		aTest.MustBeNoError(err)
+
+		// Unknown keys are an error when fields are listed.
+		err = Validate(Dict{{Key: []byte("a"), Value: Int(1)}}, &Schema{Fields: []Field{{Key: "b"}}})
+		aTest.MustBeEqual(err.Error(), "a: unknown key")
+	}
+
+	// Test #5. Unsupported value.
+	{
+		var err = Validate(time.Time{}, schema)
+		aTest.MustBeEqual(err.Error(), "(root): unsupported type")
+
+		aTest.MustBeNoError(Validate(int64(1), nil))
+	}
+}
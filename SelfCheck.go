@@ -0,0 +1,477 @@
+package bencode
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// SelfCheckExcerptRadius is the number of bytes shown in the excerpts of a
+// self-check report before and after the differing offset.
+const SelfCheckExcerptRadius = 16
+
+// SelfCheckCategory is a category of a self-check failure, i.e. the reason
+// why the encoded data differs from the source data.
+type SelfCheckCategory byte
+
+// Categories of self-check failures.
+const (
+	// SelfCheckContentMismatch means that the decoded object differs from
+	// the source data for some other reason, e.g. it has been changed.
+	SelfCheckContentMismatch SelfCheckCategory = iota + 1
+
+	// SelfCheckUnsortedKeys means that the keys of a dictionary are not
+	// sorted.
+	SelfCheckUnsortedKeys
+
+	// SelfCheckLeadingZeros means that an integer or a size header of a byte
+	// string is not written in its shortest form, e.g. 'i03e', 'i-0e' or
+	// '03:abc'.
+	SelfCheckLeadingZeros
+
+	// SelfCheckDuplicateKey means that a dictionary has duplicate keys.
+	SelfCheckDuplicateKey
+
+	// SelfCheckTrailingData means that the source data continues after the
+	// end of the decoded value.
+	SelfCheckTrailingData
+
+	// SelfCheckUnsupportedType means that the decoded object contains a
+	// value which can not be encoded.
+	SelfCheckUnsupportedType
+)
+
+// String returns the name of the category.
+func (c SelfCheckCategory) String() string {
+	switch c {
+	case SelfCheckContentMismatch:
+		return "content mismatch"
+	case SelfCheckUnsortedKeys:
+		return "unsorted keys"
+	case SelfCheckLeadingZeros:
+		return "leading zeros"
+	case SelfCheckDuplicateKey:
+		return "duplicate key"
+	case SelfCheckTrailingData:
+		return "trailing data"
+	case SelfCheckUnsupportedType:
+		return "unsupported type"
+	}
+
+	return "unknown"
+}
+
+// SelfCheckReport describes a failed self-check.
+//
+// Offset is the first offset at which the encoded data differs from the
+// source data, it is -1 when the object can not be encoded. Path is the key
+// path of the innermost value at that offset, e.g. 'info.files[0].length'.
+// SourceExcerpt and EncodedExcerpt are hexadecimal excerpts of both sides
+// around the offset, starting at ExcerptOffset.
+type SelfCheckReport struct {
+	Category       SelfCheckCategory
+	Offset         int
+	Path           string
+	ExcerptOffset  int
+	SourceExcerpt  string
+	EncodedExcerpt string
+}
+
+// String returns a human-readable description of the report.
+func (r *SelfCheckReport) String() string {
+	var path = r.Path
+	if len(path) == 0 {
+		path = "(root)"
+	}
+
+	if r.Offset < 0 {
+		return fmt.Sprintf("%v at %s", r.Category, path)
+	}
+
+	return fmt.Sprintf("%v at offset %d, %s; source [%s], encoded [%s] from offset %d",
+		r.Category, r.Offset, path, r.SourceExcerpt, r.EncodedExcerpt, r.ExcerptOffset)
+}
+
+// SelfCheckError is an error of a failed self-check.
+type SelfCheckError struct {
+	Report *SelfCheckReport
+}
+
+// Error returns the text of the error.
+func (e *SelfCheckError) Error() string {
+	return ErrSelfCheck + ": " + e.Report.String()
+}
+
+// errSelfCheckMismatch stops encoding when the self-check has failed.
+var errSelfCheckMismatch = errors.New(ErrSelfCheck)
+
+// comparingWriter compares the written data with the source data. After
+// the first mismatch it keeps a short excerpt of the written data and then
+// stops the writing with an error.
+type comparingWriter struct {
+	source     []byte
+	offset     int
+	isMismatch bool
+	tail       []byte
+}
+
+// newComparingWriter is the constructor of a comparing writer.
+func newComparingWriter(source []byte) (cw *comparingWriter) {
+	return &comparingWriter{
+		source: source,
+	}
+}
+
+// Write compares the data with the source data.
+func (cw *comparingWriter) Write(p []byte) (n int, err error) {
+	if cw.isMismatch {
+		return cw.saveTail(p)
+	}
+
+	// Fast path: the whole data matches.
+	var rest = cw.source[cw.offset:]
+	if (len(p) <= len(rest)) && bytes.Equal(p, rest[:len(p)]) {
+		cw.offset += len(p)
+		return len(p), nil
+	}
+
+	var i = 0
+	for (i < len(p)) && (i < len(rest)) && (p[i] == rest[i]) {
+		i++
+	}
+
+	cw.offset += i
+	cw.isMismatch = true
+	n, err = cw.saveTail(p[i:])
+
+	return i + n, err
+}
+
+// saveTail saves the data written after the mismatch until the excerpt is
+// long enough.
+func (cw *comparingWriter) saveTail(p []byte) (n int, err error) {
+	n = min(SelfCheckExcerptRadius-len(cw.tail), len(p))
+	cw.tail = append(cw.tail, p[:n]...)
+	if len(cw.tail) >= SelfCheckExcerptRadius {
+		return n, errSelfCheckMismatch
+	}
+
+	return n, nil
+}
+
+// newSelfCheckReport explains the first difference between the source data
+// and the data encoded into the comparing writer. The encoding error, if
+// any, is given as well. Nil is returned when there is no difference.
+func newSelfCheckReport(rawObject any, cw *comparingWriter, encodingError error) (report *SelfCheckReport) {
+	if (encodingError != nil) && !cw.isMismatch {
+		return &SelfCheckReport{
+			Category: SelfCheckUnsupportedType,
+			Offset:   -1,
+			Path:     findUnsupportedValue(rawObject),
+		}
+	}
+
+	var source = cw.source
+	var offset = cw.offset
+	if !cw.isMismatch && (offset == len(source)) {
+		return nil
+	}
+
+	// Bytes before the offset are the same on both sides.
+	report = &SelfCheckReport{
+		Category:      SelfCheckContentMismatch,
+		Offset:        offset,
+		ExcerptOffset: max(offset-SelfCheckExcerptRadius, 0),
+	}
+	report.SourceExcerpt = hexExcerpt(source, report.ExcerptOffset, offset+SelfCheckExcerptRadius)
+	report.EncodedExcerpt = hexExcerpt(source, report.ExcerptOffset, offset) + hex.EncodeToString(cw.tail)
+
+	if !cw.isMismatch {
+		report.Category = SelfCheckTrailingData
+		return report
+	}
+
+	// Look for a non-canonical construct of the source at the offset.
+	var s = &selfCheckScanner{
+		data:   source,
+		offset: offset,
+	}
+	_ = s.scanValue("")
+
+	report.Path = s.pathAtOffset
+	var problem = s.findProblem()
+	if problem != nil {
+		report.Category = problem.category
+		report.Path = problem.path
+	}
+
+	return report
+}
+
+// newKeyOrderReport reports the first key of the source data which is not
+// sorted or is duplicated. The encoder keeps keys as they were decoded, so
+// such keys do not make the encoded data differ from the source data. Nil is
+// returned when all the keys are canonical.
+func newKeyOrderReport(source []byte) (report *SelfCheckReport) {
+	var s = &selfCheckScanner{
+		data:   source,
+		offset: -1,
+	}
+	_ = s.scanValue("")
+
+	if len(s.keyProblems) == 0 {
+		return nil
+	}
+
+	// Both sides are the same.
+	var problem = s.keyProblems[0]
+	report = &SelfCheckReport{
+		Category:      problem.category,
+		Offset:        problem.start,
+		Path:          problem.path,
+		ExcerptOffset: max(problem.start-SelfCheckExcerptRadius, 0),
+	}
+	report.SourceExcerpt = hexExcerpt(source, report.ExcerptOffset, problem.start+SelfCheckExcerptRadius)
+	report.EncodedExcerpt = report.SourceExcerpt
+
+	return report
+}
+
+// hexExcerpt returns the bytes from the range [start, end) in hexadecimal
+// form. The range is limited by the data.
+func hexExcerpt(data []byte, start int, end int) string {
+	end = min(end, len(data))
+	if start >= end {
+		return ""
+	}
+
+	return hex.EncodeToString(data[start:end])
+}
+
+// findUnsupportedValue returns the path of the first value of the tree which
+// can not be encoded.
+func findUnsupportedValue(rawObject any) (path string) {
+	_ = Walk(rawObject, func(nodePath string, depth int, node any) error {
+		switch node.(type) {
+		case []DictionaryItem, []any:
+			return nil
+		}
+
+		_, err := NewEncoder().EncodeAnInterface(node)
+		if err != nil {
+			path = nodePath
+			return err
+		}
+
+		return SkipSubtree
+	})
+
+	return path
+}
+
+// selfCheckProblem is a non-canonical construct of the source data.
+type selfCheckProblem struct {
+	category SelfCheckCategory
+	start    int
+	end      int
+	path     string
+}
+
+// selfCheckScanner scans the source data searching for non-canonical
+// constructs and for the innermost value at the offset. Problems of keys
+// order are kept apart, as they never make the encoded data differ.
+type selfCheckScanner struct {
+	data         []byte
+	pos          int
+	offset       int
+	problems     []selfCheckProblem
+	keyProblems  []selfCheckProblem
+	pathAtOffset string
+	isPathFound  bool
+}
+
+// scanValue scans a value and its sub-values. Scanning stops at the first
+// syntax error.
+func (s *selfCheckScanner) scanValue(path string) (err error) {
+	if s.pos >= len(s.data) {
+		return io.ErrUnexpectedEOF
+	}
+
+	var start = s.pos
+	var b = s.data[s.pos]
+	switch {
+	case b == HeaderInteger:
+		var end = bytes.IndexByte(s.data[start:], FooterCommon)
+		if end < 0 {
+			return io.ErrUnexpectedEOF
+		}
+
+		var number = s.data[start+1 : start+end]
+		s.pos = start + end + 1
+		if hasLeadingZeros(number) {
+			s.addProblem(SelfCheckLeadingZeros, start, s.pos, path)
+		}
+
+	case isByteNonNegativeAsciiNumeric(b):
+		_, err = s.scanByteString(path)
+		if err != nil {
+			return err
+		}
+
+	case b == HeaderList:
+		s.pos++
+		for i := 0; (s.pos < len(s.data)) && (s.data[s.pos] != FooterCommon); i++ {
+			err = s.scanValue(appendPathIndex(path, i))
+			if err != nil {
+				return err
+			}
+		}
+
+		if s.pos >= len(s.data) {
+			return io.ErrUnexpectedEOF
+		}
+
+		s.pos++
+
+	case b == HeaderDictionary:
+		err = s.scanDictionary(path)
+		if err != nil {
+			return err
+		}
+
+	default:
+		return fmt.Errorf(ErrFSyntaxErrorAt, []byte{b})
+	}
+
+	s.visit(path, start, s.pos)
+
+	return nil
+}
+
+// scanDictionary scans a dictionary and checks the order and the uniqueness
+// of its keys. Each key is compared with the previous one.
+func (s *selfCheckScanner) scanDictionary(path string) (err error) {
+	s.pos++
+
+	var previousKey []byte
+	for (s.pos < len(s.data)) && (s.data[s.pos] != FooterCommon) {
+		var keyStart = s.pos
+		var key []byte
+		key, err = s.scanByteString(path)
+		if err != nil {
+			return err
+		}
+
+		var keyPath = appendPathKey(path, key)
+		if previousKey != nil {
+			switch bytes.Compare(key, previousKey) {
+			case -1:
+				s.addKeyProblem(SelfCheckUnsortedKeys, keyStart, s.pos, keyPath)
+			case 0:
+				s.addKeyProblem(SelfCheckDuplicateKey, keyStart, s.pos, keyPath)
+			}
+		}
+		previousKey = key
+
+		err = s.scanValue(keyPath)
+		if err != nil {
+			return err
+		}
+	}
+
+	if s.pos >= len(s.data) {
+		return io.ErrUnexpectedEOF
+	}
+
+	s.pos++
+
+	return nil
+}
+
+// scanByteString scans a byte string and checks its size header.
+func (s *selfCheckScanner) scanByteString(path string) (ba []byte, err error) {
+	var start = s.pos
+	var delimiter = bytes.IndexByte(s.data[start:], HeaderStringSizeValueDelimiter)
+	if delimiter < 0 {
+		return nil, io.ErrUnexpectedEOF
+	}
+
+	var header = s.data[start : start+delimiter]
+	var size uint64
+	size, err = strconv.ParseUint(string(header), 10, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	var dataStart = start + delimiter + 1
+	if size > uint64(len(s.data)-dataStart) {
+		return nil, io.ErrUnexpectedEOF
+	}
+
+	s.pos = dataStart + int(size)
+	if hasLeadingZeros(header) {
+		s.addProblem(SelfCheckLeadingZeros, start, s.pos, path)
+	}
+
+	return s.data[dataStart:s.pos], nil
+}
+
+// addProblem saves a problem of the source data.
+func (s *selfCheckScanner) addProblem(category SelfCheckCategory, start int, end int, path string) {
+	s.problems = append(s.problems, selfCheckProblem{
+		category: category,
+		start:    start,
+		end:      end,
+		path:     path,
+	})
+}
+
+// addKeyProblem saves a problem of keys order of the source data.
+func (s *selfCheckScanner) addKeyProblem(category SelfCheckCategory, start int, end int, path string) {
+	s.keyProblems = append(s.keyProblems, selfCheckProblem{
+		category: category,
+		start:    start,
+		end:      end,
+		path:     path,
+	})
+}
+
+// visit remembers the path of the scanned value if the value contains the
+// offset. Children are scanned before their parents are finished, so the
+// first such value is the innermost one.
+func (s *selfCheckScanner) visit(path string, start int, end int) {
+	if s.isPathFound || (s.offset < start) || (s.offset >= end) {
+		return
+	}
+
+	s.pathAtOffset = path
+	s.isPathFound = true
+}
+
+// findProblem returns the innermost problem containing the offset.
+func (s *selfCheckScanner) findProblem() (problem *selfCheckProblem) {
+	for i, p := range s.problems {
+		if (s.offset < p.start) || (s.offset >= p.end) {
+			continue
+		}
+
+		if (problem == nil) || (p.end-p.start < problem.end-problem.start) {
+			problem = &s.problems[i]
+		}
+	}
+
+	return problem
+}
+
+// hasLeadingZeros checks whether the textual form of a number is not the
+// shortest one.
+func hasLeadingZeros(number []byte) bool {
+	if (len(number) > 0) && (number[0] == '-') {
+		return (len(number) > 1) && (number[1] == '0')
+	}
+
+	return (len(number) > 1) && (number[0] == '0')
+}
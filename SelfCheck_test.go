@@ -0,0 +1,218 @@
+package bencode
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/vault-thirteen/auxie/tester"
+)
+
+func Test_SelfCheckCategory_String(t *testing.T) {
+	var aTest = tester.New(t)
+
+	aTest.MustBeEqual(SelfCheckUnsortedKeys.String(), "unsorted keys")
+	aTest.MustBeEqual(SelfCheckTrailingData.String(), "trailing data")
+	aTest.MustBeEqual(SelfCheckCategory(0).String(), "unknown")
+}
+
+func Test_DecodedObject_MakeSelfCheckReport(t *testing.T) {
+	var aTest = tester.New(t)
+
+	type TestData struct {
+		source    string
+		rawObject any
+		category  SelfCheckCategory
+		offset    int
+		path      string
+	}
+
+	var tests = []TestData{
+		{
+			source:    "d1:ai03ee",
+			rawObject: []DictionaryItem{{Key: []byte("a"), Value: int64(3)}},
+			category:  SelfCheckLeadingZeros,
+			offset:    5,
+			path:      "a",
+		},
+		{
+			source:    "li-0ee",
+			rawObject: []any{int64(0)},
+			category:  SelfCheckLeadingZeros,
+			offset:    2,
+			path:      "[0]",
+		},
+		{
+			source:    "d1:a03:abce",
+			rawObject: []DictionaryItem{{Key: []byte("a"), Value: []byte("abc")}},
+			category:  SelfCheckLeadingZeros,
+			offset:    4,
+			path:      "a",
+		},
+		{
+			source:    "i1ei2e",
+			rawObject: int64(1),
+			category:  SelfCheckTrailingData,
+			offset:    3,
+			path:      "",
+		},
+		{
+			source: "d4:infod6:lengthi1eee",
+			rawObject: []DictionaryItem{
+				{Key: []byte("info"), Value: []DictionaryItem{{Key: []byte("length"), Value: int64(2)}}},
+			},
+			category: SelfCheckContentMismatch,
+			offset:   17,
+			path:     "info.length",
+		},
+		{
+			source:    "...Corrupted Data...",
+			rawObject: int64(1),
+			category:  SelfCheckContentMismatch,
+			offset:    0,
+			path:      "",
+		},
+		{
+			source:    "li1ee",
+			rawObject: []any{int64(1), time.Time{}},
+			category:  SelfCheckUnsupportedType,
+			offset:    -1,
+			path:      "[1]",
+		},
+	}
+
+	for _, test := range tests {
+		var object = DecodedObject{
+			SourceData: []byte(test.source),
+			RawObject:  test.rawObject,
+		}
+
+		var report = object.MakeSelfCheckReport()
+		aTest.MustBeDifferent(report, (*SelfCheckReport)(nil))
+		aTest.MustBeEqual(report.Category, test.category)
+		aTest.MustBeEqual(report.Offset, test.offset)
+		aTest.MustBeEqual(report.Path, test.path)
+		aTest.MustBeEqual(object.IsSelfChecked, false)
+	}
+
+	// Unsorted and duplicate keys are reported, while the encoded data is the
+	// same.
+	var keyTests = []TestData{
+		{source: "d1:bi1e1:ai2ee", category: SelfCheckUnsortedKeys, offset: 7, path: "a"},
+		{source: "d1:ai1e1:ai2ee", category: SelfCheckDuplicateKey, offset: 7, path: "a"},
+		{source: "l0:d4:infod1:bi1e1:ai2eeee", category: SelfCheckUnsortedKeys, offset: 17, path: "[1].info.a"},
+	}
+	for _, test := range keyTests {
+		object, err := parseSourceData("path", []byte(test.source), ParseOptions{MakeSelfCheck: true})
+		aTest.MustBeNoError(err)
+		aTest.MustBeEqual(object.MakeSelfCheck(), true)
+
+		var report = object.MakeSelfCheckReport()
+		aTest.MustBeDifferent(report, (*SelfCheckReport)(nil))
+		aTest.MustBeEqual(report.Category, test.category)
+		aTest.MustBeEqual(report.Offset, test.offset)
+		aTest.MustBeEqual(report.Path, test.path)
+		aTest.MustBeEqual(report.SourceExcerpt, report.EncodedExcerpt)
+		aTest.MustBeEqual(object.IsSelfChecked, true)
+	}
+
+	// Canonical data.
+	{
+		object, err := parseSourceData("path", []byte("d1:ai1e1:bd1:ci1eee"), ParseOptions{})
+		aTest.MustBeNoError(err)
+		aTest.MustBeEqual(object.MakeSelfCheckReport(), (*SelfCheckReport)(nil))
+	}
+
+	// Excerpts.
+	{
+		var object = DecodedObject{
+			SourceData: []byte("d1:ai03ee"),
+			RawObject:  []DictionaryItem{{Key: []byte("a"), Value: int64(3)}},
+		}
+
+		var report = object.MakeSelfCheckReport()
+		aTest.MustBeEqual(report.ExcerptOffset, 0)
+		aTest.MustBeEqual(report.SourceExcerpt, "64313a616930336565")
+		aTest.MustBeEqual(report.EncodedExcerpt, "64313a6169336565")
+		aTest.MustBeEqual(report.String(),
+			"leading zeros at offset 5, a; source [64313a616930336565], encoded [64313a6169336565] from offset 0")
+	}
+
+	// Success.
+	{
+		var object = DecodedObject{
+			SourceData: []byte("d4:test3:abce"),
+			RawObject:  []DictionaryItem{{Key: []byte("test"), Value: []byte("abc")}},
+		}
+
+		aTest.MustBeEqual(object.MakeSelfCheckReport(), (*SelfCheckReport)(nil))
+		aTest.MustBeEqual(object.IsSelfChecked, true)
+	}
+}
+
+func Test_comparingWriter(t *testing.T) {
+	var aTest = tester.New(t)
+
+	// Test #1. Matching data.
+	{
+		var cw = newComparingWriter([]byte("d4:infoe"))
+		n, err := cw.Write([]byte("d4:in"))
+		aTest.MustBeNoError(err)
+		aTest.MustBeEqual(n, 5)
+		_, err = cw.Write([]byte("foe"))
+		aTest.MustBeNoError(err)
+		aTest.MustBeEqual(cw.offset, 8)
+		aTest.MustBeEqual(cw.isMismatch, false)
+	}
+
+	// Test #2. Writing stops soon after the first mismatch.
+	{
+		var source = bytes.Repeat([]byte{'a'}, 100)
+		var cw = newComparingWriter(source)
+		_, err := cw.Write(source[:10])
+		aTest.MustBeNoError(err)
+
+		var data = bytes.Repeat([]byte{'b'}, 90)
+		n, err := cw.Write(data)
+		aTest.MustBeAnError(err)
+		aTest.MustBeEqual(n, SelfCheckExcerptRadius)
+		aTest.MustBeEqual(cw.offset, 10)
+		aTest.MustBeEqual(cw.isMismatch, true)
+		aTest.MustBeEqual(cw.tail, data[:SelfCheckExcerptRadius])
+	}
+
+	// Test #3. Written data is longer than the source.
+	{
+		var cw = newComparingWriter([]byte("i1e"))
+		n, err := cw.Write([]byte("i1ei2e"))
+		aTest.MustBeNoError(err)
+		aTest.MustBeEqual(n, 6)
+		aTest.MustBeEqual(cw.offset, 3)
+		aTest.MustBeEqual(cw.tail, []byte("i2e"))
+	}
+}
+
+func Test_SelfCheckError(t *testing.T) {
+	var aTest = tester.New(t)
+
+	_, err := parseSourceData("path", []byte("i1ei2e"), ParseOptions{MakeSelfCheck: true})
+	aTest.MustBeAnError(err)
+
+	var selfCheckError *SelfCheckError
+	aTest.MustBeEqual(errors.As(err, &selfCheckError), true)
+	aTest.MustBeEqual(selfCheckError.Report.Category, SelfCheckTrailingData)
+	aTest.MustBeEqual(err.Error(),
+		"self-check error: trailing data at offset 3, (root); source [693165693265], encoded [693165] from offset 0")
+}
+
+func Test_hasLeadingZeros(t *testing.T) {
+	var aTest = tester.New(t)
+
+	aTest.MustBeEqual(hasLeadingZeros([]byte("0")), false)
+	aTest.MustBeEqual(hasLeadingZeros([]byte("10")), false)
+	aTest.MustBeEqual(hasLeadingZeros([]byte("-1")), false)
+	aTest.MustBeEqual(hasLeadingZeros([]byte("01")), true)
+	aTest.MustBeEqual(hasLeadingZeros([]byte("-0")), true)
+	aTest.MustBeEqual(hasLeadingZeros([]byte("-01")), true)
+}
@@ -0,0 +1,245 @@
+package bencode
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// Kind is a kind of 'bencode' value.
+type Kind byte
+
+// Kinds of 'bencode' values.
+const (
+	KindInvalid Kind = iota
+	KindInt
+	KindString
+	KindList
+	KindDict
+)
+
+// String returns the name of the kind.
+func (k Kind) String() string {
+	switch k {
+	case KindInt:
+		return "integer"
+	case KindString:
+		return "byte string"
+	case KindList:
+		return "list"
+	case KindDict:
+		return "dictionary"
+	}
+
+	return "invalid"
+}
+
+// Value is a strongly typed 'bencode' value. It is one of the following
+// types: Int, String, List or Dict.
+type Value interface {
+	Kind() Kind
+	AsInt() (i int64, err error)
+	AsBytes() (ba []byte, err error)
+	AsList() (list List, err error)
+	AsDict() (dict Dict, err error)
+}
+
+// Int is a 'bencode' integer.
+type Int int64
+
+// String is a 'bencode' byte string.
+type String []byte
+
+// List is a 'bencode' list.
+type List []Value
+
+// Dict is a 'bencode' dictionary.
+type Dict []DictEntry
+
+// DictEntry is an entry of a strongly typed 'bencode' dictionary.
+type DictEntry struct {
+	Key   []byte
+	Value Value
+}
+
+// Kind returns the kind of the value.
+func (i Int) Kind() Kind { return KindInt }
+
+// AsInt returns the integer.
+func (i Int) AsInt() (int64, error) { return int64(i), nil }
+
+// AsBytes returns an error, while the value is not a byte string.
+func (i Int) AsBytes() ([]byte, error) { return nil, kindMismatch(KindString, i) }
+
+// AsList returns an error, while the value is not a list.
+func (i Int) AsList() (List, error) { return nil, kindMismatch(KindList, i) }
+
+// AsDict returns an error, while the value is not a dictionary.
+func (i Int) AsDict() (Dict, error) { return nil, kindMismatch(KindDict, i) }
+
+// Kind returns the kind of the value.
+func (s String) Kind() Kind { return KindString }
+
+// AsInt returns an error, while the value is not an integer.
+func (s String) AsInt() (int64, error) { return 0, kindMismatch(KindInt, s) }
+
+// AsBytes returns the byte string.
+func (s String) AsBytes() ([]byte, error) { return s, nil }
+
+// AsList returns an error, while the value is not a list.
+func (s String) AsList() (List, error) { return nil, kindMismatch(KindList, s) }
+
+// AsDict returns an error, while the value is not a dictionary.
+func (s String) AsDict() (Dict, error) { return nil, kindMismatch(KindDict, s) }
+
+// Kind returns the kind of the value.
+func (l List) Kind() Kind { return KindList }
+
+// AsInt returns an error, while the value is not an integer.
+func (l List) AsInt() (int64, error) { return 0, kindMismatch(KindInt, l) }
+
+// AsBytes returns an error, while the value is not a byte string.
+func (l List) AsBytes() ([]byte, error) { return nil, kindMismatch(KindString, l) }
+
+// AsList returns the list.
+func (l List) AsList() (List, error) { return l, nil }
+
+// AsDict returns an error, while the value is not a dictionary.
+func (l List) AsDict() (Dict, error) { return nil, kindMismatch(KindDict, l) }
+
+// Kind returns the kind of the value.
+func (d Dict) Kind() Kind { return KindDict }
+
+// AsInt returns an error, while the value is not an integer.
+func (d Dict) AsInt() (int64, error) { return 0, kindMismatch(KindInt, d) }
+
+// AsBytes returns an error, while the value is not a byte string.
+func (d Dict) AsBytes() ([]byte, error) { return nil, kindMismatch(KindString, d) }
+
+// AsList returns an error, while the value is not a list.
+func (d Dict) AsList() (List, error) { return nil, kindMismatch(KindList, d) }
+
+// AsDict returns the dictionary.
+func (d Dict) AsDict() (Dict, error) { return d, nil }
+
+// kindMismatch creates an error of a wrong kind of value.
+func kindMismatch(expected Kind, received Value) error {
+	return fmt.Errorf(ErrFKindMismatch, expected, received.Kind())
+}
+
+// ToValue converts a value of the current representation, i.e. a value
+// returned by the decoder or accepted by the encoder, into a strongly typed
+// value.
+func ToValue(raw any) (v Value, err error) {
+	switch x := raw.(type) {
+	case Value:
+		return x, nil
+	case int64:
+		return Int(x), nil
+	case int:
+		return Int(x), nil
+	case int8:
+		return Int(x), nil
+	case int16:
+		return Int(x), nil
+	case int32:
+		return Int(x), nil
+	case uint8:
+		return Int(x), nil
+	case uint16:
+		return Int(x), nil
+	case uint32:
+		return Int(x), nil
+	case uint:
+		return toIntValue(uint64(x))
+	case uint64:
+		return toIntValue(x)
+	case []byte:
+		return String(x), nil
+	case string:
+		return String(x), nil
+	case []any:
+		return toListValue(x)
+	case []DictionaryItem:
+		return toDictValue(x)
+	case Dictionary:
+		return toDictValue(x)
+	}
+
+	return nil, errors.New(ErrDataType)
+}
+
+// toIntValue converts an unsigned integer into a strongly typed value.
+func toIntValue(u uint64) (v Value, err error) {
+	if u > math.MaxInt64 {
+		return nil, fmt.Errorf(ErrFIntegerOverflow, u)
+	}
+
+	return Int(u), nil
+}
+
+// toListValue converts a list into a strongly typed value.
+func toListValue(list []any) (v Value, err error) {
+	var result = make(List, 0, len(list))
+	for _, item := range list {
+		var itemValue Value
+		itemValue, err = ToValue(item)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, itemValue)
+	}
+
+	return result, nil
+}
+
+// toDictValue converts a dictionary into a strongly typed value.
+func toDictValue(dictionary []DictionaryItem) (v Value, err error) {
+	var result = make(Dict, 0, len(dictionary))
+	for _, item := range dictionary {
+		var itemValue Value
+		itemValue, err = ToValue(item.Value)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, DictEntry{Key: item.Key, Value: itemValue})
+	}
+
+	return result, nil
+}
+
+// FromValue converts a strongly typed value into the representation used by
+// the decoder: int64, []byte, []any or []DictionaryItem.
+func FromValue(v Value) (raw any) {
+	switch x := v.(type) {
+	case Int:
+		return int64(x)
+
+	case String:
+		return []byte(x)
+
+	case List:
+		var list = make([]any, 0, len(x))
+		for _, item := range x {
+			list = append(list, FromValue(item))
+		}
+		return list
+
+	case Dict:
+		var dictionary = make([]DictionaryItem, 0, len(x))
+		for _, entry := range x {
+			var value = FromValue(entry.Value)
+			dictionary = append(dictionary, DictionaryItem{
+				Key:      entry.Key,
+				Value:    value,
+				KeyStr:   string(entry.Key),
+				ValueStr: convertInterfaceToString(value),
+			})
+		}
+		return dictionary
+	}
+
+	return nil
+}
@@ -0,0 +1,115 @@
+package bencode
+
+import (
+	"bufio"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/vault-thirteen/auxie/tester"
+)
+
+func Test_Kind_String(t *testing.T) {
+	var aTest = tester.New(t)
+
+	aTest.MustBeEqual(KindInt.String(), "integer")
+	aTest.MustBeEqual(KindString.String(), "byte string")
+	aTest.MustBeEqual(KindList.String(), "list")
+	aTest.MustBeEqual(KindDict.String(), "dictionary")
+	aTest.MustBeEqual(KindInvalid.String(), "invalid")
+}
+
+func Test_Value_accessors(t *testing.T) {
+	var aTest = tester.New(t)
+
+	var values = []Value{Int(1), String("a"), List{Int(2)}, Dict{{Key: []byte("k"), Value: Int(3)}}}
+	for i, value := range values {
+		aTest.MustBeEqual(value.Kind(), Kind(i+1))
+
+		_, err := value.AsInt()
+		aTest.MustBeEqual(err == nil, value.Kind() == KindInt)
+
+		_, err = value.AsBytes()
+		aTest.MustBeEqual(err == nil, value.Kind() == KindString)
+
+		_, err = value.AsList()
+		aTest.MustBeEqual(err == nil, value.Kind() == KindList)
+
+		_, err = value.AsDict()
+		aTest.MustBeEqual(err == nil, value.Kind() == KindDict)
+	}
+
+	_, err := String("a").AsInt()
+	aTest.MustBeEqual(err.Error(), "kind mismatch: integer is expected, byte string is received")
+}
+
+func Test_ToValue(t *testing.T) {
+	var aTest = tester.New(t)
+
+	// Test #1. Positive.
+	{
+		value, err := ToValue([]DictionaryItem{
+			{Key: []byte("a"), Value: []any{int64(1), "two", uint8(3)}},
+		})
+		aTest.MustBeNoError(err)
+		aTest.MustBeEqual(value, Value(Dict{
+			{Key: []byte("a"), Value: List{Int(1), String("two"), Int(3)}},
+		}))
+	}
+
+	// Test #2. Negative: unsupported type.
+	{
+		_, err := ToValue([]any{time.Time{}})
+		aTest.MustBeAnError(err)
+	}
+
+	// Test #3. Negative: overflow.
+	{
+		_, err := ToValue(uint64(math.MaxUint64))
+		aTest.MustBeAnError(err)
+	}
+}
+
+func Test_FromValue(t *testing.T) {
+	var aTest = tester.New(t)
+
+	var raw = FromValue(Dict{
+		{Key: []byte("a"), Value: List{Int(1), String("two")}},
+	})
+	aTest.MustBeEqual(raw, []DictionaryItem{
+		{
+			Key:    []byte("a"),
+			Value:  []any{int64(1), []byte("two")},
+			KeyStr: "a",
+		},
+	})
+	aTest.MustBeEqual(FromValue(nil), nil)
+}
+
+func Test_Value_encodeAndDecode(t *testing.T) {
+	var aTest = tester.New(t)
+
+	var source = "d1:ad1:bli1e2:xyee1:ci-5ee"
+
+	var decoder = NewDecoder(bufio.NewReader(strings.NewReader(source)))
+	value, err := decoder.DecodeValue()
+	aTest.MustBeNoError(err)
+	aTest.MustBeEqual(value, Value(Dict{
+		{Key: []byte("a"), Value: Dict{{Key: []byte("b"), Value: List{Int(1), String("xy")}}}},
+		{Key: []byte("c"), Value: Int(-5)},
+	}))
+
+	encoded, err := NewEncoder().EncodeAnInterface(value)
+	aTest.MustBeNoError(err)
+	aTest.MustBeEqual(string(encoded), source)
+
+	// Generic decoding.
+	value, err = DecodeBytes[Value]([]byte(source))
+	aTest.MustBeNoError(err)
+	aTest.MustBeEqual(value.Kind(), KindDict)
+
+	// A list with a missing value.
+	_, err = NewEncoder().EncodeAnInterface(List{nil})
+	aTest.MustBeAnError(err)
+}
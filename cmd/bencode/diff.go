@@ -0,0 +1,46 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/vault-thirteen/bencode"
+)
+
+// runDiff lists the differences between two files.
+func runDiff(args []string, stdin io.Reader, stdout io.Writer) (exitCode int, err error) {
+	if len(args) != 2 {
+		return ExitCodeError, errors.New(ErrArgumentsCount)
+	}
+
+	var objects = make([]*bencode.DecodedObject, 0, len(args))
+	for _, filePath := range args {
+		var object *bencode.DecodedObject
+		object, err = bencode.NewFile(filePath).Parse(false)
+		if err != nil {
+			return ExitCodeError, err
+		}
+
+		objects = append(objects, object)
+	}
+
+	var changes []bencode.Change
+	changes, err = bencode.Diff(objects[0].RawObject, objects[1].RawObject)
+	if err != nil {
+		return ExitCodeError, err
+	}
+
+	for _, change := range changes {
+		_, err = fmt.Fprintln(stdout, change.String())
+		if err != nil {
+			return ExitCodeError, err
+		}
+	}
+
+	if len(changes) > 0 {
+		return ExitCodeDifferences, nil
+	}
+
+	return ExitCodeSuccess, nil
+}
@@ -0,0 +1,6 @@
+package main
+
+// Error messages.
+const (
+	ErrArgumentsCount = "wrong number of arguments"
+)
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"errors"
+	"io"
+	"os"
+
+	ae "github.com/vault-thirteen/auxie/errors"
+	"github.com/vault-thirteen/bencode"
+)
+
+// runJSON converts a file or the standard input into JSON.
+func runJSON(args []string, stdin io.Reader, stdout io.Writer) (exitCode int, err error) {
+	if len(args) > 1 {
+		return ExitCodeError, errors.New(ErrArgumentsCount)
+	}
+
+	var input = stdin
+	if len(args) == 1 {
+		var file *os.File
+		file, err = os.Open(args[0])
+		if err != nil {
+			return ExitCodeError, err
+		}
+
+		defer func() {
+			derr := file.Close()
+			if derr != nil {
+				err = ae.Combine(err, derr)
+			}
+		}()
+
+		input = file
+	}
+
+	err = bencode.TranscodeToJSON(input, stdout)
+	if err != nil {
+		return ExitCodeError, err
+	}
+
+	return ExitCodeSuccess, nil
+}
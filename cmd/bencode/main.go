@@ -0,0 +1,83 @@
+// Bencode is a command line tool for 'bencoded' files.
+//
+// Usage:
+//
+//	bencode diff FILE_A FILE_B
+//	bencode json [FILE]
+//
+// The 'diff' command lists the differences between two files by key path.
+// The exit code is 1 when the files differ.
+//
+// The 'json' command converts a file, or the standard input when no file is
+// given, into JSON. The conversion is made in a streaming manner, each value
+// of the input is written on a separate line.
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+)
+
+// Exit codes.
+const (
+	ExitCodeSuccess     = 0
+	ExitCodeDifferences = 1
+	ExitCodeError       = 2
+)
+
+// command is a sub-command of the tool.
+type command struct {
+	usage string
+	run   func(args []string, stdin io.Reader, stdout io.Writer) (exitCode int, err error)
+}
+
+// commands are the sub-commands of the tool.
+var commands = map[string]command{
+	"diff": {
+		usage: "diff FILE_A FILE_B",
+		run:   runDiff,
+	},
+	"json": {
+		usage: "json [FILE]",
+		run:   runJSON,
+	},
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdin io.Reader, stdout io.Writer, stderr io.Writer) (exitCode int) {
+	if len(args) == 0 {
+		printUsage(stderr)
+		return ExitCodeError
+	}
+
+	var cmd, ok = commands[args[0]]
+	if !ok {
+		printUsage(stderr)
+		return ExitCodeError
+	}
+
+	var err error
+	exitCode, err = cmd.run(args[1:], stdin, stdout)
+	if err != nil {
+		_, _ = fmt.Fprintln(stderr, err.Error())
+		return ExitCodeError
+	}
+
+	return exitCode
+}
+
+func printUsage(w io.Writer) {
+	var lines = make([]string, 0, len(commands))
+	for _, cmd := range commands {
+		lines = append(lines, "  bencode "+cmd.usage)
+	}
+	sort.Strings(lines)
+
+	_, _ = fmt.Fprintf(w, "Usage:\n%s\n", strings.Join(lines, "\n"))
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/vault-thirteen/auxie/tester"
+)
+
+// writeTestFile creates a temporary file with the contents.
+func writeTestFile(t *testing.T, name string, contents string) (filePath string) {
+	var aTest = tester.New(t)
+
+	filePath = filepath.Join(t.TempDir(), name)
+	var err = os.WriteFile(filePath, []byte(contents), 0644)
+	aTest.MustBeNoError(err)
+
+	return filePath
+}
+
+func Test_run(t *testing.T) {
+	var aTest = tester.New(t)
+
+	var stdout, stderr bytes.Buffer
+
+	// Test #1. No command.
+	{
+		aTest.MustBeEqual(run(nil, nil, &stdout, &stderr), ExitCodeError)
+		aTest.MustBeDifferent(stderr.Len(), 0)
+	}
+
+	// Test #2. Unknown command.
+	{
+		stderr.Reset()
+		aTest.MustBeEqual(run([]string{"unknown"}, nil, &stdout, &stderr), ExitCodeError)
+		aTest.MustBeDifferent(stderr.Len(), 0)
+	}
+}
+
+func Test_runDiff(t *testing.T) {
+	var aTest = tester.New(t)
+
+	var fileA = writeTestFile(t, "a.torrent", "d8:announce3:one4:infod6:lengthi1eee")
+	var fileB = writeTestFile(t, "b.torrent", "d8:announce3:two4:infod6:lengthi1eee")
+
+	var stdout, stderr bytes.Buffer
+
+	// Test #1. Differences.
+	{
+		aTest.MustBeEqual(run([]string{"diff", fileA, fileB}, nil, &stdout, &stderr), ExitCodeDifferences)
+		aTest.MustBeEqual(stdout.String(), "~ announce: \"one\" → \"two\"\n")
+	}
+
+	// Test #2. Equal files.
+	{
+		stdout.Reset()
+		aTest.MustBeEqual(run([]string{"diff", fileA, fileA}, nil, &stdout, &stderr), ExitCodeSuccess)
+		aTest.MustBeEqual(stdout.Len(), 0)
+	}
+
+	// Test #3. Negative.
+	{
+		aTest.MustBeEqual(run([]string{"diff", fileA}, nil, &stdout, &stderr), ExitCodeError)
+		aTest.MustBeEqual(run([]string{"diff", fileA, fileA + ".none"}, nil, &stdout, &stderr), ExitCodeError)
+	}
+}
+
+func Test_runJSON(t *testing.T) {
+	var aTest = tester.New(t)
+
+	var file = writeTestFile(t, "a.torrent", "d8:announce3:one4:infod6:pieces2:\x00\xffee")
+
+	var stdout, stderr bytes.Buffer
+
+	// Test #1. File.
+	{
+		aTest.MustBeEqual(run([]string{"json", file}, nil, &stdout, &stderr), ExitCodeSuccess)
+		aTest.MustBeEqual(stdout.String(), `{"announce":"one","info":{"pieces":{"$bytes":"AP8="}}}`+"\n")
+	}
+
+	// Test #2. Standard input.
+	{
+		stdout.Reset()
+		aTest.MustBeEqual(run([]string{"json"}, strings.NewReader("i1ei2e"), &stdout, &stderr), ExitCodeSuccess)
+		aTest.MustBeEqual(stdout.String(), "1\n2\n")
+	}
+
+	// Test #3. Negative.
+	{
+		aTest.MustBeEqual(run([]string{"json", file, file}, nil, &stdout, &stderr), ExitCodeError)
+		aTest.MustBeEqual(run([]string{"json", file + ".none"}, nil, &stdout, &stderr), ExitCodeError)
+		aTest.MustBeEqual(run([]string{"json"}, strings.NewReader("x"), &stdout, &stderr), ExitCodeError)
+	}
+}
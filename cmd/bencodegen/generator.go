@@ -0,0 +1,478 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Generator settings.
+const (
+	DirectiveGenerate  = "//bencode:generate"
+	StructTagName      = "bencode"
+	TagOptionOmitEmpty = "omitempty"
+	PackageImportPath  = "github.com/vault-thirteen/bencode"
+	GeneratedHeader    = "// Code generated by bencodegen. DO NOT EDIT."
+)
+
+// Error messages and formats.
+const (
+	ErrNothingToGenerate = "no structures are marked for generation"
+	ErrFDuplicateKey     = "%v: duplicate key: %v"
+	ErrFUnsupportedType  = "%v: unsupported field type: %v"
+)
+
+// Kinds of field types.
+const (
+	kindString = iota
+	kindBytes
+	kindSignedInteger
+	kindUnsignedInteger
+	kindBool
+	kindList
+	kindStruct
+	kindStructPointer
+)
+
+// fieldType is a type of structure's field understood by the generator.
+type fieldType struct {
+	kind int
+
+	// Go name of the type, as written in the source code.
+	name string
+
+	// Type of list elements.
+	element *fieldType
+}
+
+// field is a structure's field.
+type field struct {
+	name      string
+	key       string
+	omitEmpty bool
+	typ       *fieldType
+}
+
+// structure is a structure marked for generation.
+type structure struct {
+	name   string
+	fields []*field
+}
+
+// Generator creates 'bencode' marshalling methods for structures of a Go
+// source file.
+type Generator struct {
+	fileSet     *token.FileSet
+	file        *ast.File
+	structNames map[string]bool
+	structures  []*structure
+	buffer      *bytes.Buffer
+}
+
+// NewGenerator is a generator's constructor.
+func NewGenerator(fileName string, source any) (g *Generator, err error) {
+	g = &Generator{
+		fileSet:     token.NewFileSet(),
+		structNames: make(map[string]bool),
+		buffer:      new(bytes.Buffer),
+	}
+
+	g.file, err = parser.ParseFile(g.fileSet, fileName, source, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+
+	return g, nil
+}
+
+// Generate creates the source code of the methods.
+func (g *Generator) Generate() (code []byte, err error) {
+	var specs = g.findMarkedStructures()
+	if len(specs) == 0 {
+		return nil, errors.New(ErrNothingToGenerate)
+	}
+
+	for _, spec := range specs {
+		g.structNames[spec.Name.Name] = true
+	}
+
+	for _, spec := range specs {
+		var s *structure
+		s, err = g.analyzeStructure(spec)
+		if err != nil {
+			return nil, err
+		}
+
+		g.structures = append(g.structures, s)
+	}
+
+	g.printf("%s\n\npackage %s\n\n", GeneratedHeader, g.file.Name.Name)
+	g.printf("import %q\n", PackageImportPath)
+
+	for _, s := range g.structures {
+		g.generateAppend(s)
+		g.generateMarshal(s)
+		g.generateUnmarshal(s)
+	}
+
+	return format.Source(g.buffer.Bytes())
+}
+
+// findMarkedStructures finds the structures marked with the directive.
+func (g *Generator) findMarkedStructures() (specs []*ast.TypeSpec) {
+	for _, decl := range g.file.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok || (genDecl.Tok != token.TYPE) {
+			continue
+		}
+
+		for _, spec := range genDecl.Specs {
+			typeSpec := spec.(*ast.TypeSpec)
+			if _, ok = typeSpec.Type.(*ast.StructType); !ok {
+				continue
+			}
+
+			var doc = typeSpec.Doc
+			if (doc == nil) && (len(genDecl.Specs) == 1) {
+				doc = genDecl.Doc
+			}
+
+			if hasDirective(doc) {
+				specs = append(specs, typeSpec)
+			}
+		}
+	}
+
+	return specs
+}
+
+// hasDirective checks whether the comment contains the generation directive.
+func hasDirective(doc *ast.CommentGroup) bool {
+	if doc == nil {
+		return false
+	}
+
+	for _, comment := range doc.List {
+		if strings.TrimSpace(comment.Text) == DirectiveGenerate {
+			return true
+		}
+	}
+
+	return false
+}
+
+// analyzeStructure collects the fields of a structure. Fields are sorted by
+// their keys, so that the generated code writes canonical dictionaries.
+func (g *Generator) analyzeStructure(spec *ast.TypeSpec) (s *structure, err error) {
+	s = &structure{
+		name: spec.Name.Name,
+	}
+
+	var keys = make(map[string]bool)
+	for _, astField := range spec.Type.(*ast.StructType).Fields.List {
+		var key, omitEmpty, skip = parseTag(astField.Tag)
+		if skip {
+			continue
+		}
+
+		for _, name := range astField.Names {
+			if !name.IsExported() {
+				continue
+			}
+
+			var f = &field{
+				name:      name.Name,
+				key:       key,
+				omitEmpty: omitEmpty,
+			}
+			if len(f.key) == 0 {
+				f.key = name.Name
+			}
+
+			if keys[f.key] {
+				return nil, fmt.Errorf(ErrFDuplicateKey, g.fileSet.Position(name.Pos()), f.key)
+			}
+			keys[f.key] = true
+
+			f.typ, err = g.analyzeType(astField.Type)
+			if err != nil {
+				return nil, err
+			}
+
+			s.fields = append(s.fields, f)
+		}
+	}
+
+	sort.Slice(s.fields, func(i, j int) bool {
+		return s.fields[i].key < s.fields[j].key
+	})
+
+	return s, nil
+}
+
+// parseTag parses the 'bencode' tag of a field.
+func parseTag(tag *ast.BasicLit) (key string, omitEmpty bool, skip bool) {
+	if tag == nil {
+		return "", false, false
+	}
+
+	var tagValue, err = strconv.Unquote(tag.Value)
+	if err != nil {
+		return "", false, false
+	}
+
+	var parts = strings.Split(reflect.StructTag(tagValue).Get(StructTagName), ",")
+	if parts[0] == "-" {
+		return "", false, true
+	}
+
+	for _, option := range parts[1:] {
+		if option == TagOptionOmitEmpty {
+			omitEmpty = true
+		}
+	}
+
+	return parts[0], omitEmpty, false
+}
+
+// analyzeType converts a type expression into a field type.
+func (g *Generator) analyzeType(expr ast.Expr) (ft *fieldType, err error) {
+	ft = &fieldType{
+		name: g.typeName(expr),
+	}
+
+	switch t := expr.(type) {
+	case *ast.Ident:
+		switch t.Name {
+		case "string":
+			ft.kind = kindString
+		case "int", "int8", "int16", "int32", "int64":
+			ft.kind = kindSignedInteger
+		case "uint", "uint8", "uint16", "uint32", "uint64", "byte":
+			ft.kind = kindUnsignedInteger
+		case "bool":
+			ft.kind = kindBool
+		default:
+			if !g.structNames[t.Name] {
+				return nil, fmt.Errorf(ErrFUnsupportedType, g.fileSet.Position(expr.Pos()), ft.name)
+			}
+			ft.kind = kindStruct
+		}
+		return ft, nil
+
+	case *ast.ArrayType:
+		if t.Len != nil {
+			break
+		}
+
+		if ident, ok := t.Elt.(*ast.Ident); ok && ((ident.Name == "byte") || (ident.Name == "uint8")) {
+			ft.kind = kindBytes
+			return ft, nil
+		}
+
+		ft.kind = kindList
+		ft.element, err = g.analyzeType(t.Elt)
+		if err != nil {
+			return nil, err
+		}
+		return ft, nil
+
+	case *ast.StarExpr:
+		if ident, ok := t.X.(*ast.Ident); ok && g.structNames[ident.Name] {
+			ft.kind = kindStructPointer
+			return ft, nil
+		}
+	}
+
+	return nil, fmt.Errorf(ErrFUnsupportedType, g.fileSet.Position(expr.Pos()), ft.name)
+}
+
+// typeName returns the source code of a type expression.
+func (g *Generator) typeName(expr ast.Expr) string {
+	var buffer bytes.Buffer
+	_ = format.Node(&buffer, g.fileSet, expr)
+	return buffer.String()
+}
+
+// printf writes the formatted code into the buffer.
+func (g *Generator) printf(format string, args ...any) {
+	_, _ = fmt.Fprintf(g.buffer, format, args...)
+}
+
+// print writes the code into the buffer.
+func (g *Generator) print(code string) {
+	_, _ = g.buffer.WriteString(code)
+}
+
+// generateAppend generates the AppendBencode method.
+func (g *Generator) generateAppend(s *structure) {
+	g.printf("\n// AppendBencode appends the 'bencoded' %s to the byte array.\n", s.name)
+	g.printf("func (obj %s) AppendBencode(dst []byte) (result []byte, err error) {\n", s.name)
+	g.printf("result = append(dst, bencode.HeaderDictionary)\n")
+
+	for _, f := range s.fields {
+		var value = "obj." + f.name
+		var condition = emptinessCheck(f, value)
+		if len(condition) > 0 {
+			g.printf("if %s {\n", condition)
+		}
+
+		g.printf("result = bencode.AppendString(result, %q)\n", f.key)
+		g.generateAppendValue(f.typ, value, 0)
+
+		if len(condition) > 0 {
+			g.printf("}\n")
+		}
+	}
+
+	g.printf("return append(result, bencode.FooterCommon), nil\n")
+	g.printf("}\n")
+}
+
+// emptinessCheck returns a condition of writing a field, if there is any.
+// Nil pointers are never written.
+func emptinessCheck(f *field, value string) (condition string) {
+	if f.typ.kind == kindStructPointer {
+		return value + " != nil"
+	}
+
+	if !f.omitEmpty {
+		return ""
+	}
+
+	switch f.typ.kind {
+	case kindString, kindBytes, kindList:
+		return "len(" + value + ") > 0"
+	case kindSignedInteger, kindUnsignedInteger:
+		return value + " != 0"
+	case kindBool:
+		return value
+	}
+
+	return ""
+}
+
+// generateAppendValue generates the code appending a value.
+func (g *Generator) generateAppendValue(ft *fieldType, value string, depth int) {
+	switch ft.kind {
+	case kindString:
+		g.printf("result = bencode.AppendString(result, %s)\n", value)
+
+	case kindBytes:
+		g.printf("result = bencode.AppendByteString(result, %s)\n", value)
+
+	case kindSignedInteger:
+		g.printf("result = bencode.AppendInteger(result, int64(%s))\n", value)
+
+	case kindUnsignedInteger:
+		g.printf("result = bencode.AppendUInteger(result, uint64(%s))\n", value)
+
+	case kindBool:
+		g.printf("result = bencode.AppendBool(result, %s)\n", value)
+
+	case kindList:
+		var element = fmt.Sprintf("element%d", depth)
+		g.printf("result = append(result, bencode.HeaderList)\n")
+		g.printf("for _, %s := range %s {\n", element, value)
+		if ft.element.kind == kindStructPointer {
+			// Nil pointers are never written.
+			g.printf("if %s == nil {\ncontinue\n}\n", element)
+		}
+		g.generateAppendValue(ft.element, element, depth+1)
+		g.printf("}\n")
+		g.printf("result = append(result, bencode.FooterCommon)\n")
+
+	case kindStruct, kindStructPointer:
+		g.printf("result, err = %s.AppendBencode(result)\n", value)
+		g.printf("if err != nil {\nreturn nil, err\n}\n")
+	}
+}
+
+// generateMarshal generates the MarshalBencode method.
+func (g *Generator) generateMarshal(s *structure) {
+	g.printf("\n// MarshalBencode encodes the %s into the 'bencode' format.\n", s.name)
+	g.printf("func (obj %s) MarshalBencode() (data []byte, err error) {\n", s.name)
+	g.printf("return obj.AppendBencode(nil)\n")
+	g.printf("}\n")
+}
+
+// generateUnmarshal generates the UnmarshalBencode method and its helper.
+func (g *Generator) generateUnmarshal(s *structure) {
+	g.printf("\n// UnmarshalBencode decodes the %s from the 'bencode' format.\n", s.name)
+	g.printf("func (obj *%s) UnmarshalBencode(data []byte) (err error) {\n", s.name)
+	g.printf("var dictionary []bencode.DictionaryItem\n")
+	g.printf("dictionary, err = bencode.DecodeBytes[[]bencode.DictionaryItem](data)\n")
+	g.printf("if err != nil {\nreturn err\n}\n\n")
+	g.printf("return obj.unmarshalBencodeDictionary(dictionary)\n")
+	g.printf("}\n")
+
+	g.printf("\n// unmarshalBencodeDictionary fills the %s with a decoded dictionary.\n", s.name)
+	g.printf("// Unknown keys are ignored.\n")
+	g.printf("func (obj *%s) unmarshalBencodeDictionary(dictionary []bencode.DictionaryItem) (err error) {\n", s.name)
+	if len(s.fields) > 0 {
+		g.printf("for _, item := range dictionary {\n")
+		g.printf("switch string(item.Key) {\n")
+		for _, f := range s.fields {
+			g.printf("case %q:\n", f.key)
+			g.generateUnmarshalValue(f.typ, "obj."+f.name, "item.Value", 0)
+		}
+		g.printf("}\n")
+		g.printf("}\n\n")
+	}
+	g.printf("return nil\n")
+	g.printf("}\n")
+}
+
+// generateUnmarshalValue generates the code converting a decoded value.
+func (g *Generator) generateUnmarshalValue(ft *fieldType, target string, source string, depth int) {
+	var checkError = "if err != nil {\nreturn err\n}\n"
+
+	switch ft.kind {
+	case kindString:
+		g.printf("%s, err = bencode.UnmarshalString(%s)\n", target, source)
+		g.print(checkError)
+
+	case kindBytes:
+		g.printf("%s, err = bencode.UnmarshalByteString(%s)\n", target, source)
+		g.print(checkError)
+
+	case kindSignedInteger, kindUnsignedInteger:
+		g.printf("%s, err = bencode.UnmarshalInteger[%s](%s)\n", target, ft.name, source)
+		g.print(checkError)
+
+	case kindBool:
+		g.printf("%s, err = bencode.UnmarshalBool(%s)\n", target, source)
+		g.print(checkError)
+
+	case kindList:
+		var list = fmt.Sprintf("list%d", depth)
+		var index = fmt.Sprintf("i%d", depth)
+		var element = fmt.Sprintf("element%d", depth)
+		g.printf("var %s []any\n", list)
+		g.printf("%s, err = bencode.UnmarshalList(%s)\n", list, source)
+		g.print(checkError)
+		g.printf("%s = make(%s, len(%s))\n", target, ft.name, list)
+		g.printf("for %s, %s := range %s {\n", index, element, list)
+		g.generateUnmarshalValue(ft.element, target+"["+index+"]", element, depth+1)
+		g.printf("}\n")
+
+	case kindStruct, kindStructPointer:
+		var dictionary = fmt.Sprintf("dictionary%d", depth)
+		g.printf("var %s []bencode.DictionaryItem\n", dictionary)
+		g.printf("%s, err = bencode.UnmarshalDictionary(%s)\n", dictionary, source)
+		g.print(checkError)
+		if ft.kind == kindStructPointer {
+			g.printf("%s = new(%s)\n", target, strings.TrimPrefix(ft.name, "*"))
+		}
+		g.printf("err = %s.unmarshalBencodeDictionary(%s)\n", target, dictionary)
+		g.print(checkError)
+	}
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"go/ast"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/vault-thirteen/auxie/tester"
+)
+
+// Test Settings.
+const (
+	TestSampleFolder = "internal/sample"
+	TestSampleFile   = "krpc.go"
+)
+
+func Test_Generator_Generate(t *testing.T) {
+	var aTest = tester.New(t)
+
+	// Test #1. Positive: the generated sample is up-to-date.
+	{
+		var inputFilePath = filepath.Join(TestSampleFolder, TestSampleFile)
+		g, err := NewGenerator(inputFilePath, nil)
+		aTest.MustBeNoError(err)
+
+		code, err := g.Generate()
+		aTest.MustBeNoError(err)
+
+		expectedCode, err := os.ReadFile(filepath.Join(TestSampleFolder, "krpc"+OutputFileSuffix))
+		aTest.MustBeNoError(err)
+		aTest.MustBeEqual(string(code), string(expectedCode))
+	}
+
+	// Test #2. Negative: nothing is marked.
+	{
+		g, err := NewGenerator("a.go", "package a\n\ntype A struct{}\n")
+		aTest.MustBeNoError(err)
+
+		_, err = g.Generate()
+		aTest.MustBeAnError(err)
+	}
+
+	// Test #3. Negative: unsupported type.
+	{
+		g, err := NewGenerator("a.go", "package a\n\n//bencode:generate\ntype A struct{ M map[string]int }\n")
+		aTest.MustBeNoError(err)
+
+		_, err = g.Generate()
+		aTest.MustBeAnError(err)
+	}
+
+	// Test #4. Negative: duplicate key.
+	{
+		g, err := NewGenerator("a.go", "package a\n\n//bencode:generate\ntype A struct {\n\tX int `bencode:\"k\"`\n\tY int `bencode:\"k\"`\n}\n")
+		aTest.MustBeNoError(err)
+
+		_, err = g.Generate()
+		aTest.MustBeAnError(err)
+	}
+
+	// Test #5. Positive: nil elements of a list of pointers are skipped.
+	{
+		g, err := NewGenerator("a.go", "package a\n\n//bencode:generate\ntype A struct {\n\tL []*A `bencode:\"l\"`\n}\n")
+		aTest.MustBeNoError(err)
+
+		code, err := g.Generate()
+		aTest.MustBeNoError(err)
+		aTest.MustBeEqual(strings.Contains(string(code), "if element0 == nil {\n\t\t\tcontinue\n\t\t}\n\t\tresult, err = element0.AppendBencode(result)"), true)
+	}
+}
+
+func Test_parseTag(t *testing.T) {
+	var aTest = tester.New(t)
+
+	// Test #1. No tag.
+	{
+		key, omitEmpty, skip := parseTag(nil)
+		aTest.MustBeEqual(key, "")
+		aTest.MustBeEqual(omitEmpty, false)
+		aTest.MustBeEqual(skip, false)
+	}
+
+	// Test #2. Key with an option.
+	{
+		key, omitEmpty, skip := parseTag(&ast.BasicLit{Kind: token.STRING, Value: "`bencode:\"v,omitempty\"`"})
+		aTest.MustBeEqual(key, "v")
+		aTest.MustBeEqual(omitEmpty, true)
+		aTest.MustBeEqual(skip, false)
+	}
+
+	// Test #3. Skipped field.
+	{
+		_, _, skip := parseTag(&ast.BasicLit{Kind: token.STRING, Value: "`bencode:\"-\"`"})
+		aTest.MustBeEqual(skip, true)
+	}
+}
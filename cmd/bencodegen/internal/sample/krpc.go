@@ -0,0 +1,33 @@
+// Package sample contains structures used for testing the generator. The
+// methods are generated with the following command.
+//
+//go:generate go run ../.. krpc.go
+package sample
+
+// Query is a KRPC query message.
+//
+//bencode:generate
+type Query struct {
+	TransactionID []byte       `bencode:"t"`
+	Type          string       `bencode:"y"`
+	Method        string       `bencode:"q"`
+	Arguments     Arguments    `bencode:"a"`
+	Version       []byte       `bencode:"v,omitempty"`
+	ReadOnly      bool         `bencode:"ro,omitempty"`
+	Origin        *Arguments   `bencode:"o"`
+	Hops          []*Arguments `bencode:"h,omitempty"`
+	internal      int
+}
+
+// Arguments are arguments of a KRPC query.
+//
+//bencode:generate
+type Arguments struct {
+	ID       []byte   `bencode:"id"`
+	Target   []byte   `bencode:"target,omitempty"`
+	Port     uint16   `bencode:"port,omitempty"`
+	Want     []string `bencode:"want,omitempty"`
+	Values   [][]byte `bencode:"values,omitempty"`
+	Sequence int64    `bencode:"seq"`
+	Skipped  string   `bencode:"-"`
+}
@@ -0,0 +1,79 @@
+package sample
+
+import (
+	"testing"
+
+	"github.com/vault-thirteen/auxie/tester"
+	"github.com/vault-thirteen/bencode"
+)
+
+func Test_Query_MarshalBencode(t *testing.T) {
+	var aTest = tester.New(t)
+
+	var query = Query{
+		TransactionID: []byte("aa"),
+		Type:          "q",
+		Method:        "get_peers",
+		Arguments: Arguments{
+			ID:       []byte("abcdefghij0123456789"),
+			Port:     6881,
+			Want:     []string{"n4", "n6"},
+			Sequence: -1,
+			Skipped:  "Skipped",
+		},
+		internal: 1,
+	}
+
+	var data, err = query.MarshalBencode()
+	aTest.MustBeNoError(err)
+	aTest.MustBeEqual(string(data), "d1:ad2:id20:abcdefghij01234567894:porti6881e3:seqi-1e4:wantl2:n42:n6ee1:q9:get_peers1:t2:aa1:y1:qe")
+
+	// The encoder uses the generated method.
+	var encoded []byte
+	encoded, err = bencode.NewEncoder().EncodeAnInterface(query)
+	aTest.MustBeNoError(err)
+	aTest.MustBeEqual(encoded, data)
+
+	// Nil elements of a list of pointers are not written.
+	query.Hops = []*Arguments{{ID: []byte("x")}, nil}
+	data, err = query.MarshalBencode()
+	aTest.MustBeNoError(err)
+	aTest.MustBeEqual(string(data), "d1:ad2:id20:abcdefghij01234567894:porti6881e3:seqi-1e4:wantl2:n42:n6ee1:hld2:id1:x3:seqi0eee1:q9:get_peers1:t2:aa1:y1:qe")
+}
+
+func Test_Query_UnmarshalBencode(t *testing.T) {
+	var aTest = tester.New(t)
+
+	var query Query
+	var err error
+
+	// Test #1. Positive.
+	{
+		err = query.UnmarshalBencode([]byte("d1:ad2:id2:ab6:valuesl1:x1:yee1:od2:id2:cde1:q4:ping2:roi1e1:t2:aa1:v4:LT011:y1:q1:zi0ee"))
+		aTest.MustBeNoError(err)
+		aTest.MustBeEqual(query, Query{
+			TransactionID: []byte("aa"),
+			Type:          "q",
+			Method:        "ping",
+			Arguments: Arguments{
+				ID:     []byte("ab"),
+				Values: [][]byte{[]byte("x"), []byte("y")},
+			},
+			Version:  []byte("LT01"),
+			ReadOnly: true,
+			Origin:   &Arguments{ID: []byte("cd")},
+		})
+	}
+
+	// Test #2. Negative: integer overflow.
+	{
+		err = query.UnmarshalBencode([]byte("d1:ad4:porti70000eee"))
+		aTest.MustBeAnError(err)
+	}
+
+	// Test #3. Negative: wrong type.
+	{
+		err = query.UnmarshalBencode([]byte("d1:qi1ee"))
+		aTest.MustBeAnError(err)
+	}
+}
@@ -0,0 +1,78 @@
+// Bencodegen generates reflection-free 'bencode' marshalling methods for Go
+// structures.
+//
+// A structure is marked for generation with a '//bencode:generate' comment
+// line. Keys of a dictionary are taken from 'bencode' tags of the fields, e.g.
+// `bencode:"t"` or `bencode:"token,omitempty"`; fields tagged with "-" are
+// skipped. Supported field types are strings, byte arrays, integers, booleans,
+// other marked structures, pointers to them and slices of all of these.
+//
+// Usage with 'go generate':
+//
+//	//go:generate bencodegen
+//
+// The methods are written into a file with the '_bencode.go' suffix placed
+// next to the source file.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+)
+
+// Settings.
+const (
+	EnvGoFile        = "GOFILE"
+	OutputFileSuffix = "_bencode.go"
+	OutputFileMode   = 0644
+)
+
+func main() {
+	var outputFilePath = flag.String("output", "", "path of the output file")
+	flag.Usage = func() {
+		_, _ = fmt.Fprintln(flag.CommandLine.Output(), "Usage: bencodegen [-output file] [file.go]")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	var inputFilePath = os.Getenv(EnvGoFile)
+	if flag.NArg() > 0 {
+		inputFilePath = flag.Arg(0)
+	}
+	if len(inputFilePath) == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if len(*outputFilePath) == 0 {
+		*outputFilePath = strings.TrimSuffix(inputFilePath, ".go") + OutputFileSuffix
+	}
+
+	var err = generate(inputFilePath, *outputFilePath)
+	mustBeNoError(err)
+}
+
+func mustBeNoError(err error) {
+	if err != nil {
+		log.Fatalln(err.Error())
+	}
+}
+
+func generate(inputFilePath string, outputFilePath string) (err error) {
+	var g *Generator
+	g, err = NewGenerator(inputFilePath, nil)
+	if err != nil {
+		return err
+	}
+
+	var code []byte
+	code, err = g.Generate()
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(outputFilePath, code, OutputFileMode)
+}
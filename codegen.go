@@ -0,0 +1,114 @@
+package bencode
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// Helpers for the code produced by the 'bencodegen' tool. The generated code
+// writes straight into a byte array and does not use reflection.
+
+// Marshaler is an object which is able to encode itself into the 'bencode'
+// format.
+type Marshaler interface {
+	MarshalBencode() (data []byte, err error)
+}
+
+// Unmarshaler is an object which is able to decode itself from the 'bencode'
+// format.
+type Unmarshaler interface {
+	UnmarshalBencode(data []byte) (err error)
+}
+
+// AppendByteString appends a 'bencode' byte string to the byte array.
+func AppendByteString(dst []byte, ba []byte) (result []byte) {
+	result = strconv.AppendUint(dst, uint64(len(ba)), 10)
+	result = append(result, HeaderStringSizeValueDelimiter)
+	return append(result, ba...)
+}
+
+// AppendString appends a text as a 'bencode' byte string to the byte array.
+func AppendString(dst []byte, s string) (result []byte) {
+	result = strconv.AppendUint(dst, uint64(len(s)), 10)
+	result = append(result, HeaderStringSizeValueDelimiter)
+	return append(result, s...)
+}
+
+// AppendInteger appends a signed 'bencode' integer to the byte array.
+func AppendInteger(dst []byte, value int64) (result []byte) {
+	result = append(dst, HeaderInteger)
+	result = strconv.AppendInt(result, value, 10)
+	return append(result, FooterCommon)
+}
+
+// AppendUInteger appends an unsigned 'bencode' integer to the byte array.
+func AppendUInteger(dst []byte, value uint64) (result []byte) {
+	result = append(dst, HeaderInteger)
+	result = strconv.AppendUint(result, value, 10)
+	return append(result, FooterCommon)
+}
+
+// AppendBool appends a boolean as a 'bencode' integer (0 or 1) to the byte
+// array.
+func AppendBool(dst []byte, value bool) (result []byte) {
+	if value {
+		return AppendInteger(dst, 1)
+	}
+
+	return AppendInteger(dst, 0)
+}
+
+// UnmarshalByteString converts a decoded value into a byte string.
+func UnmarshalByteString(value any) (ba []byte, err error) {
+	return convertDecodedValue[[]byte](value)
+}
+
+// UnmarshalString converts a decoded byte string into a text.
+func UnmarshalString(value any) (s string, err error) {
+	return convertDecodedValue[string](value)
+}
+
+// UnmarshalInteger converts a decoded integer into the T type. An integer
+// which does not fit into the T type is an error.
+func UnmarshalInteger[T int | int8 | int16 | int32 | int64 | uint | uint8 | uint16 | uint32 | uint64](value any) (result T, err error) {
+	var i64 int64
+	i64, err = convertDecodedValue[int64](value)
+	if err != nil {
+		return 0, err
+	}
+
+	result = T(i64)
+	if (int64(result) != i64) || ((result < 0) != (i64 < 0)) {
+		return 0, fmt.Errorf(ErrFIntegerOverflow, i64)
+	}
+
+	return result, nil
+}
+
+// UnmarshalBool converts a decoded integer (0 or 1) into a boolean.
+func UnmarshalBool(value any) (result bool, err error) {
+	var i64 int64
+	i64, err = convertDecodedValue[int64](value)
+	if err != nil {
+		return false, err
+	}
+
+	switch i64 {
+	case 0:
+		return false, nil
+	case 1:
+		return true, nil
+	}
+
+	return false, fmt.Errorf(ErrFIntegerOverflow, i64)
+}
+
+// UnmarshalList converts a decoded value into a list.
+func UnmarshalList(value any) (list []any, err error) {
+	return convertDecodedValue[[]any](value)
+}
+
+// UnmarshalDictionary converts a decoded value into a dictionary.
+func UnmarshalDictionary(value any) (dictionary []DictionaryItem, err error) {
+	return convertDecodedValue[[]DictionaryItem](value)
+}
@@ -0,0 +1,156 @@
+package bencode
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/binary"
+	"hash/fnv"
+	"slices"
+)
+
+// Equal checks whether two values are equal. Values may be given in any form
+// accepted by ToValue, e.g. a decoded value may be compared with a
+// strongly typed one. Integers are compared by their value, text strings are
+// byte strings. Dictionaries are equal when they have the same keys with
+// equal values, regardless of the order of keys; the derived fields of
+// DictionaryItem are ignored. Unsupported values are never equal.
+func Equal(a, b any) bool {
+	var va, vb Value
+	var err error
+	va, err = ToValue(a)
+	if err != nil {
+		return false
+	}
+
+	vb, err = ToValue(b)
+	if err != nil {
+		return false
+	}
+
+	return compareValues(va, vb) == 0
+}
+
+// Compare compares two values and returns -1, 0 or +1. Values of different
+// kinds are ordered as follows: integers, byte strings, lists, dictionaries.
+// Integers are compared by their value, byte strings lexicographically, lists
+// element by element. Dictionaries are compared as lists of items sorted by
+// key. Unsupported values are placed before all others.
+func Compare(a, b any) int {
+	var va, errA = ToValue(a)
+	var vb, errB = ToValue(b)
+
+	if (errA != nil) || (errB != nil) {
+		return cmp.Compare(boolToInt(errA == nil), boolToInt(errB == nil))
+	}
+
+	return compareValues(va, vb)
+}
+
+// Hash returns a hash sum of a value. Equal values have equal hash sums, so
+// that the sum may be used as a key of a map; a collision should be resolved
+// with the Equal function.
+func Hash(v any) (sum uint64, err error) {
+	var value Value
+	value, err = ToValue(v)
+	if err != nil {
+		return 0, err
+	}
+
+	var h = fnv.New64a()
+	var buffer []byte
+	buffer = appendHashData(buffer, value)
+	_, _ = h.Write(buffer)
+
+	return h.Sum64(), nil
+}
+
+// compareValues compares two strongly typed values.
+func compareValues(a, b Value) int {
+	var kindA, kindB = valueKind(a), valueKind(b)
+	if kindA != kindB {
+		return cmp.Compare(kindA, kindB)
+	}
+
+	switch x := a.(type) {
+	case Int:
+		return cmp.Compare(x, b.(Int))
+
+	case String:
+		return bytes.Compare(x, b.(String))
+
+	case List:
+		return slices.CompareFunc(x, b.(List), compareValues)
+
+	case Dict:
+		return slices.CompareFunc(sortDictEntries(x), sortDictEntries(b.(Dict)), compareDictEntries)
+	}
+
+	// Nil values.
+	return 0
+}
+
+// compareDictEntries compares two dictionary entries by key and then by value.
+func compareDictEntries(a, b DictEntry) int {
+	var result = bytes.Compare(a.Key, b.Key)
+	if result != 0 {
+		return result
+	}
+
+	return compareValues(a.Value, b.Value)
+}
+
+// sortDictEntries returns a copy of dictionary entries sorted by key and
+// value. The original dictionary is not changed.
+func sortDictEntries(dict Dict) Dict {
+	var sorted = slices.Clone(dict)
+	slices.SortFunc(sorted, compareDictEntries)
+
+	return sorted
+}
+
+// valueKind returns the kind of a value which may be nil.
+func valueKind(v Value) Kind {
+	if v == nil {
+		return KindInvalid
+	}
+
+	return v.Kind()
+}
+
+// appendHashData appends the data used for hashing a value.
+func appendHashData(dst []byte, v Value) []byte {
+	dst = append(dst, byte(valueKind(v)))
+
+	switch x := v.(type) {
+	case Int:
+		dst = binary.BigEndian.AppendUint64(dst, uint64(x))
+
+	case String:
+		dst = binary.BigEndian.AppendUint64(dst, uint64(len(x)))
+		dst = append(dst, x...)
+
+	case List:
+		dst = binary.BigEndian.AppendUint64(dst, uint64(len(x)))
+		for _, item := range x {
+			dst = appendHashData(dst, item)
+		}
+
+	case Dict:
+		dst = binary.BigEndian.AppendUint64(dst, uint64(len(x)))
+		for _, entry := range sortDictEntries(x) {
+			dst = appendHashData(dst, String(entry.Key))
+			dst = appendHashData(dst, entry.Value)
+		}
+	}
+
+	return dst
+}
+
+// boolToInt converts a boolean into an integer.
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+
+	return 0
+}
@@ -0,0 +1,95 @@
+package bencode
+
+import (
+	"testing"
+	"time"
+
+	"github.com/vault-thirteen/auxie/tester"
+)
+
+func Test_Equal(t *testing.T) {
+	var aTest = tester.New(t)
+
+	// Test #1. Derived fields and the order of keys are ignored.
+	{
+		var a = []DictionaryItem{
+			{Key: []byte("a"), Value: []byte("x"), KeyStr: "a", ValueStr: "x"},
+			{Key: []byte("b"), Value: []any{int64(1)}},
+		}
+		var b = []DictionaryItem{
+			{Key: []byte("b"), Value: []any{1}},
+			{Key: []byte("a"), Value: "x"},
+		}
+		aTest.MustBeEqual(Equal(a, b), true)
+		aTest.MustBeEqual(Equal(a, Dict{{Key: []byte("a"), Value: String("x")}, {Key: []byte("b"), Value: List{Int(1)}}}), true)
+	}
+
+	// Test #2. Different values.
+	{
+		aTest.MustBeEqual(Equal(int64(1), int64(2)), false)
+		aTest.MustBeEqual(Equal(int64(1), []byte("1")), false)
+		aTest.MustBeEqual(Equal([]any{int64(1)}, []any{int64(1), int64(2)}), false)
+		aTest.MustBeEqual(Equal(
+			[]DictionaryItem{{Key: []byte("a"), Value: int64(1)}},
+			[]DictionaryItem{{Key: []byte("a"), Value: int64(2)}},
+		), false)
+	}
+
+	// Test #3. Unsupported values.
+	{
+		aTest.MustBeEqual(Equal(time.Time{}, time.Time{}), false)
+	}
+}
+
+func Test_Compare(t *testing.T) {
+	var aTest = tester.New(t)
+
+	aTest.MustBeEqual(Compare(int64(1), int64(2)), -1)
+	aTest.MustBeEqual(Compare(uint8(2), int64(2)), 0)
+	aTest.MustBeEqual(Compare([]byte("b"), "a"), 1)
+	aTest.MustBeEqual(Compare(int64(100), []byte("a")), -1)
+	aTest.MustBeEqual(Compare([]any{int64(1)}, []DictionaryItem{}), -1)
+	aTest.MustBeEqual(Compare([]any{int64(1), int64(2)}, []any{int64(1)}), 1)
+	aTest.MustBeEqual(Compare(
+		[]DictionaryItem{{Key: []byte("a"), Value: int64(1)}},
+		[]DictionaryItem{{Key: []byte("b"), Value: int64(0)}},
+	), -1)
+	aTest.MustBeEqual(Compare(time.Time{}, int64(1)), -1)
+	aTest.MustBeEqual(Compare(int64(1), time.Time{}), 1)
+}
+
+func Test_Hash(t *testing.T) {
+	var aTest = tester.New(t)
+
+	// Test #1. Equal values.
+	{
+		h1, err := Hash([]DictionaryItem{
+			{Key: []byte("a"), Value: int64(1), KeyStr: "a"},
+			{Key: []byte("b"), Value: []byte("x")},
+		})
+		aTest.MustBeNoError(err)
+
+		h2, err := Hash(Dictionary{
+			{Key: []byte("b"), Value: "x"},
+			{Key: []byte("a"), Value: 1},
+		})
+		aTest.MustBeNoError(err)
+		aTest.MustBeEqual(h1, h2)
+	}
+
+	// Test #2. Different values.
+	{
+		h1, err := Hash([]any{[]byte("ab"), []byte("c")})
+		aTest.MustBeNoError(err)
+
+		h2, err := Hash([]any{[]byte("a"), []byte("bc")})
+		aTest.MustBeNoError(err)
+		aTest.MustBeDifferent(h1, h2)
+	}
+
+	// Test #3. Unsupported value.
+	{
+		_, err := Hash(time.Time{})
+		aTest.MustBeAnError(err)
+	}
+}
@@ -0,0 +1,213 @@
+package bencode
+
+import (
+	"bufio"
+	"bytes"
+	"compress/bzip2"
+	"compress/gzip"
+	"compress/zlib"
+	"fmt"
+	"io"
+)
+
+// DecompressedSizeMaxDefault is the default maximal size of decompressed
+// data. Decompression stops with an error when the data becomes bigger, so
+// that a small compressed file can not exhaust the memory.
+const DecompressedSizeMaxDefault = 256 * 1024 * 1024
+
+// Compression is a compression format of input data.
+type Compression byte
+
+// Compression formats.
+const (
+	CompressionNone Compression = iota
+	CompressionGzip
+	CompressionZlib
+	CompressionBzip2
+)
+
+// Magic bytes of compression formats.
+const (
+	gzipMagic  = "\x1f\x8b"
+	bzip2Magic = "BZh"
+)
+
+// compressionMagicMaxLength is the number of bytes needed to detect a
+// compression format.
+const compressionMagicMaxLength = 4
+
+// String returns the name of the compression format.
+func (c Compression) String() string {
+	switch c {
+	case CompressionNone:
+		return "none"
+	case CompressionGzip:
+		return "gzip"
+	case CompressionZlib:
+		return "zlib"
+	case CompressionBzip2:
+		return "bzip2"
+	}
+
+	return "unknown"
+}
+
+// DetectCompression detects the compression format of data by its first
+// bytes. None of the formats may start a 'bencoded' value, so that plain
+// data is never taken for compressed data.
+func DetectCompression(header []byte) (compression Compression) {
+	if bytes.HasPrefix(header, []byte(gzipMagic)) {
+		return CompressionGzip
+	}
+
+	if bytes.HasPrefix(header, []byte(bzip2Magic)) && (len(header) > len(bzip2Magic)) &&
+		(header[len(bzip2Magic)] >= '1') && (header[len(bzip2Magic)] <= '9') {
+		return CompressionBzip2
+	}
+
+	if (len(header) >= 2) && isZlibHeader(header[0], header[1]) {
+		return CompressionZlib
+	}
+
+	return CompressionNone
+}
+
+// isZlibHeader checks the header of a zlib stream: the 'deflate' method, a
+// valid window size and the checksum. Headers starting with an ASCII digit
+// are rejected as they may start a byte string.
+func isZlibHeader(cmf byte, flg byte) bool {
+	if isByteNonNegativeAsciiNumeric(cmf) {
+		return false
+	}
+
+	return (cmf&0x0F == 8) && (cmf>>4 <= 7) && ((uint16(cmf)<<8|uint16(flg))%31 == 0)
+}
+
+// NewDecompressingDecoder creates a decoder which detects compressed input
+// and decompresses it on the fly. Plain input is decoded as is. Reading
+// stops with an error when the decompressed data becomes bigger than the
+// maximal size; zero size means the default maximal size.
+func NewDecompressingDecoder(r io.Reader, maxSize int64) (d *Decoder, err error) {
+	var reader = bufio.NewReader(r)
+
+	// Errors of peeking, e.g. a short input, are found by the decoder.
+	var header, _ = reader.Peek(compressionMagicMaxLength)
+	var compression = DetectCompression(header)
+	if compression == CompressionNone {
+		return NewDecoder(reader), nil
+	}
+
+	var decompressor io.Reader
+	decompressor, err = newDecompressor(compression, reader)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewDecoder(bufio.NewReader(newSizeLimitedReader(decompressor, maxSize))), nil
+}
+
+// decompressData decompresses the data if it is compressed. Plain data is
+// returned as is.
+func decompressData(data []byte, maxSize int64) (result []byte, compression Compression, err error) {
+	compression = DetectCompression(data)
+	if compression == CompressionNone {
+		return data, compression, nil
+	}
+
+	var decompressor io.Reader
+	decompressor, err = newDecompressor(compression, bytes.NewReader(data))
+	if err != nil {
+		return nil, compression, err
+	}
+
+	result, err = io.ReadAll(newSizeLimitedReader(decompressor, maxSize))
+	if err != nil {
+		return nil, compression, err
+	}
+
+	return result, compression, nil
+}
+
+// compressData compresses the data with the compression format. Plain data is
+// returned as is. There is no compressor of the 'bzip2' format in the
+// standard library, so such data can not be written.
+func compressData(data []byte, compression Compression) (result []byte, err error) {
+	var buffer bytes.Buffer
+	var compressor io.WriteCloser
+	switch compression {
+	case CompressionNone:
+		return data, nil
+	case CompressionGzip:
+		compressor = gzip.NewWriter(&buffer)
+	case CompressionZlib:
+		compressor = zlib.NewWriter(&buffer)
+	default:
+		return nil, fmt.Errorf(ErrFCompressionWrite, compression)
+	}
+
+	_, err = compressor.Write(data)
+	if err != nil {
+		return nil, err
+	}
+
+	err = compressor.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+}
+
+// newDecompressor creates a reader of decompressed data.
+func newDecompressor(compression Compression, r io.Reader) (decompressor io.Reader, err error) {
+	switch compression {
+	case CompressionGzip:
+		return gzip.NewReader(r)
+	case CompressionZlib:
+		return zlib.NewReader(r)
+	case CompressionBzip2:
+		return bzip2.NewReader(r), nil
+	}
+
+	return r, nil
+}
+
+// sizeLimitedReader is a reader which fails when the data is too big.
+type sizeLimitedReader struct {
+	reader    io.Reader
+	maxSize   int64
+	remaining int64
+}
+
+// newSizeLimitedReader is the constructor of a size limited reader. Zero
+// size means the default maximal size.
+func newSizeLimitedReader(r io.Reader, maxSize int64) (slr *sizeLimitedReader) {
+	if maxSize <= 0 {
+		maxSize = DecompressedSizeMaxDefault
+	}
+
+	return &sizeLimitedReader{
+		reader:    r,
+		maxSize:   maxSize,
+		remaining: maxSize,
+	}
+}
+
+// Read reads the data. One byte more than allowed is requested to find out
+// whether the data is too big.
+func (slr *sizeLimitedReader) Read(p []byte) (n int, err error) {
+	if int64(len(p)) > slr.remaining+1 {
+		p = p[:slr.remaining+1]
+	}
+
+	n, err = slr.reader.Read(p)
+	if int64(n) > slr.remaining {
+		n = int(slr.remaining)
+		slr.remaining = 0
+		return n, fmt.Errorf(ErrFDecompressedSize, slr.maxSize)
+	}
+
+	slr.remaining -= int64(n)
+
+	return n, err
+}
@@ -0,0 +1,174 @@
+package bencode
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/vault-thirteen/auxie/tester"
+)
+
+// testBzip2Data is 'd4:info3:Sune' compressed with bzip2.
+const testBzip2Data = "425a68393141592653597dad9a5c0000028b800c100800072182002000220d01908069a683626b0b0492f1772453850907dad9a5c0"
+
+func compressTestData(t *testing.T, compression Compression, data []byte) (result []byte) {
+	var aTest = tester.New(t)
+
+	var buffer bytes.Buffer
+	var err error
+	switch compression {
+	case CompressionGzip:
+		var w = gzip.NewWriter(&buffer)
+		_, err = w.Write(data)
+		aTest.MustBeNoError(err)
+		err = w.Close()
+
+	case CompressionZlib:
+		var w = zlib.NewWriter(&buffer)
+		_, err = w.Write(data)
+		aTest.MustBeNoError(err)
+		err = w.Close()
+
+	case CompressionBzip2:
+		aTest.MustBeEqual(string(data), TestFileBContents)
+		result, err = hex.DecodeString(testBzip2Data)
+		aTest.MustBeNoError(err)
+		return result
+	}
+	aTest.MustBeNoError(err)
+
+	return buffer.Bytes()
+}
+
+func Test_Compression_String(t *testing.T) {
+	var aTest = tester.New(t)
+
+	aTest.MustBeEqual(CompressionGzip.String(), "gzip")
+	aTest.MustBeEqual(CompressionBzip2.String(), "bzip2")
+	aTest.MustBeEqual(Compression(99).String(), "unknown")
+}
+
+func Test_DetectCompression(t *testing.T) {
+	var aTest = tester.New(t)
+
+	var data = []byte(TestFileBContents)
+	aTest.MustBeEqual(DetectCompression(data), CompressionNone)
+	aTest.MustBeEqual(DetectCompression(nil), CompressionNone)
+	aTest.MustBeEqual(DetectCompression([]byte("80:")), CompressionNone)
+	aTest.MustBeEqual(DetectCompression([]byte("BZh")), CompressionNone)
+	aTest.MustBeEqual(DetectCompression([]byte{0x78, 0x00}), CompressionNone)
+
+	for _, compression := range []Compression{CompressionGzip, CompressionZlib, CompressionBzip2} {
+		aTest.MustBeEqual(DetectCompression(compressTestData(t, compression, data)), compression)
+	}
+}
+
+func Test_decompressData(t *testing.T) {
+	var aTest = tester.New(t)
+
+	var data = []byte(TestFileBContents)
+
+	// Test #1. Plain data.
+	{
+		result, compression, err := decompressData(data, 0)
+		aTest.MustBeNoError(err)
+		aTest.MustBeEqual(&result[0] == &data[0], true)
+		aTest.MustBeEqual(compression, CompressionNone)
+	}
+
+	// Test #2. Compressed data.
+	for _, c := range []Compression{CompressionGzip, CompressionZlib, CompressionBzip2} {
+		result, compression, err := decompressData(compressTestData(t, c, data), 0)
+		aTest.MustBeNoError(err)
+		aTest.MustBeEqual(result, data)
+		aTest.MustBeEqual(compression, c)
+	}
+
+	// Test #3. Negative: the limit is exceeded.
+	{
+		_, _, err := decompressData(compressTestData(t, CompressionGzip, data), int64(len(data)-1))
+		aTest.MustBeAnError(err)
+
+		_, _, err = decompressData(compressTestData(t, CompressionGzip, data), int64(len(data)))
+		aTest.MustBeNoError(err)
+	}
+
+	// Test #4. Negative: broken data.
+	{
+		var broken = compressTestData(t, CompressionGzip, data)
+		_, _, err := decompressData(broken[:len(broken)-4], 0)
+		aTest.MustBeAnError(err)
+	}
+}
+
+func Test_NewDecompressingDecoder(t *testing.T) {
+	var aTest = tester.New(t)
+
+	var data = []byte(strings.Repeat("li1ee", 1000))
+
+	// Test #1. Plain and compressed data.
+	for _, c := range []Compression{CompressionNone, CompressionGzip, CompressionZlib} {
+		var input = data
+		if c != CompressionNone {
+			input = compressTestData(t, c, data)
+		}
+
+		d, err := NewDecompressingDecoder(bytes.NewReader(input), 0)
+		aTest.MustBeNoError(err)
+		result, err := d.Decode()
+		aTest.MustBeNoError(err)
+		aTest.MustBeEqual(result, []any{int64(1)})
+	}
+
+	// Test #2. Negative: the limit is exceeded while decoding.
+	{
+		var input = compressTestData(t, CompressionGzip, []byte("l"+strings.Repeat("i1e", 1000)+"e"))
+		d, err := NewDecompressingDecoder(bytes.NewReader(input), 100)
+		aTest.MustBeNoError(err)
+		_, err = d.Decode()
+		aTest.MustBeAnError(err)
+	}
+
+	// Test #3. Negative: bad gzip header.
+	{
+		_, err := NewDecompressingDecoder(bytes.NewReader([]byte{0x1f, 0x8b, 0x00}), 0)
+		aTest.MustBeAnError(err)
+	}
+}
+
+func Test_File_Parse_compressed(t *testing.T) {
+	var aTest = tester.New(t)
+
+	// Test Initialization.
+	createTestFolder(t)
+	var compressed = compressTestData(t, CompressionGzip, []byte(TestFileBContents))
+	var filePath = filepath.Join(TestFolder, TestFileBName+".gz")
+	err := os.WriteFile(filePath, compressed, 0644)
+	aTest.MustBeNoError(err)
+
+	// Test Finalization.
+	defer func() {
+		deleteTestFolder(t)
+	}()
+
+	// Test #1. Positive.
+	{
+		do, err := NewFile(filePath).Parse(true)
+		aTest.MustBeNoError(err)
+		aTest.MustBeEqual(do.SourceData, []byte(TestFileBContents))
+		aTest.MustBeEqual(do.FileSize, int64(len(compressed)))
+		aTest.MustBeEqual(do.Compression, CompressionGzip)
+		aTest.MustBeEqual(do.IsSelfChecked, true)
+	}
+
+	// Test #2. Negative: the limit is exceeded.
+	{
+		_, err := NewFile(filePath).ParseWithOptions(ParseOptions{MaxDecompressedSize: 5})
+		aTest.MustBeAnError(err)
+	}
+}
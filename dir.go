@@ -0,0 +1,166 @@
+package bencode
+
+import (
+	"context"
+	"io/fs"
+	"iter"
+	"path/filepath"
+	"runtime"
+	"sync"
+)
+
+// ParseDirOptions are the options of parsing a directory.
+//
+// Patterns are glob patterns of file names, e.g. '*.torrent'; a file is
+// parsed if its name matches any of them, all files are parsed when there
+// are no patterns. Concurrency is the number of files parsed at the same
+// time, it is the number of processors when not set. Files are parsed with
+// the parse options.
+type ParseDirOptions struct {
+	Patterns     []string
+	Concurrency  int
+	ParseOptions ParseOptions
+}
+
+// ParseDirError is an error of parsing a file of a directory.
+type ParseDirError struct {
+	Path string
+	Err  error
+}
+
+// Error returns the text of the error.
+func (e *ParseDirError) Error() string {
+	return e.Path + ": " + e.Err.Error()
+}
+
+// Unwrap returns the original error.
+func (e *ParseDirError) Unwrap() error {
+	return e.Err
+}
+
+// parseDirResult is a result of parsing a file of a directory.
+type parseDirResult struct {
+	object *DecodedObject
+	err    error
+}
+
+// ParseDir walks the directory tree and parses every regular file matching
+// the patterns. Files are parsed concurrently, the results are yielded in
+// the order of completion. Errors of parsing and of reading directories are
+// yielded as ParseDirError and do not stop the walk. When the context is
+// cancelled, the walk stops and the error of the context is yielded last.
+// Stopping the iteration stops the walk as well.
+func ParseDir(ctx context.Context, root string, options ParseDirOptions) iter.Seq2[*DecodedObject, error] {
+	return func(yield func(*DecodedObject, error) bool) {
+
+		// Check the patterns.
+		for _, pattern := range options.Patterns {
+			_, err := filepath.Match(pattern, "")
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+		}
+
+		var concurrency = options.Concurrency
+		if concurrency <= 0 {
+			concurrency = runtime.GOMAXPROCS(0)
+		}
+
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		var paths = make(chan string)
+		var results = make(chan parseDirResult)
+		var wg sync.WaitGroup
+
+		// The walker.
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer close(paths)
+
+			_ = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+				if err != nil {
+					sendParseDirResult(ctx, results, parseDirResult{err: &ParseDirError{Path: path, Err: err}})
+					return ctx.Err()
+				}
+
+				if !d.Type().IsRegular() || !matchesAnyPattern(d.Name(), options.Patterns) {
+					return ctx.Err()
+				}
+
+				select {
+				case paths <- path:
+					return nil
+				case <-ctx.Done():
+					return ctx.Err()
+				}
+			})
+		}()
+
+		// The workers.
+		for range concurrency {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+
+				for path := range paths {
+					object, err := NewFile(path).ParseWithOptions(options.ParseOptions)
+					if err != nil {
+						err = &ParseDirError{Path: path, Err: err}
+					}
+
+					if !sendParseDirResult(ctx, results, parseDirResult{object: object, err: err}) {
+						return
+					}
+				}
+			}()
+		}
+
+		go func() {
+			wg.Wait()
+			close(results)
+		}()
+
+		for result := range results {
+			if !yield(result.object, result.err) {
+				cancel()
+				for range results {
+				}
+				return
+			}
+		}
+
+		if ctx.Err() != nil {
+			yield(nil, ctx.Err())
+		}
+	}
+}
+
+// sendParseDirResult sends the result unless the context is cancelled.
+func sendParseDirResult(ctx context.Context, results chan<- parseDirResult, result parseDirResult) (ok bool) {
+	select {
+	case results <- result:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// matchesAnyPattern checks whether the name matches any of the patterns.
+// Any name matches an empty list of patterns.
+func matchesAnyPattern(name string, patterns []string) bool {
+	if len(patterns) == 0 {
+		return true
+	}
+
+	for _, pattern := range patterns {
+		ok, _ := filepath.Match(pattern, name)
+		if ok {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,120 @@
+package bencode
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/vault-thirteen/auxie/tester"
+)
+
+func createParseDirTestTree(t *testing.T) (root string) {
+	var aTest = tester.New(t)
+
+	root = t.TempDir()
+	var files = map[string]string{
+		"a.torrent":             "d4:name1:ae",
+		"b.fastresume":          "i1e",
+		"notes.txt":             "not bencode",
+		"sub/c.torrent":         "d4:name1:ce",
+		"sub/deep/bad.torrent":  "d4:name",
+		"sub/deep/d.torrent":    "li1ei2ee",
+		"sub/deep/e.torrent.gz": "ignored",
+	}
+	for name, contents := range files {
+		var path = filepath.Join(root, filepath.FromSlash(name))
+		err := os.MkdirAll(filepath.Dir(path), 0755)
+		aTest.MustBeNoError(err)
+		err = os.WriteFile(path, []byte(contents), 0644)
+		aTest.MustBeNoError(err)
+	}
+
+	return root
+}
+
+func Test_ParseDir(t *testing.T) {
+	var aTest = tester.New(t)
+	var root = createParseDirTestTree(t)
+
+	// Test #1. Positive with errors.
+	{
+		var parsed []string
+		var failed []string
+		var options = ParseDirOptions{
+			Patterns:     []string{"*.torrent", "*.fastresume"},
+			Concurrency:  3,
+			ParseOptions: ParseOptions{MakeSelfCheck: true},
+		}
+		for object, err := range ParseDir(context.Background(), root, options) {
+			if err != nil {
+				var parseDirError *ParseDirError
+				aTest.MustBeEqual(errors.As(err, &parseDirError), true)
+				failed = append(failed, filepath.Base(parseDirError.Path))
+				continue
+			}
+
+			aTest.MustBeEqual(object.IsSelfChecked, true)
+			parsed = append(parsed, filepath.Base(object.FilePath))
+		}
+
+		sort.Strings(parsed)
+		aTest.MustBeEqual(parsed, []string{"a.torrent", "b.fastresume", "c.torrent", "d.torrent"})
+		aTest.MustBeEqual(failed, []string{"bad.torrent"})
+	}
+
+	// Test #2. All files, stopping early.
+	{
+		var count = 0
+		for range ParseDir(context.Background(), root, ParseDirOptions{}) {
+			count++
+			if count == 2 {
+				break
+			}
+		}
+		aTest.MustBeEqual(count, 2)
+	}
+
+	// Test #3. Cancelled context.
+	{
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		var lastError error
+		for _, err := range ParseDir(ctx, root, ParseDirOptions{}) {
+			lastError = err
+		}
+		aTest.MustBeEqual(errors.Is(lastError, context.Canceled), true)
+	}
+
+	// Test #4. Negative: bad pattern.
+	{
+		var errorsCount = 0
+		for object, err := range ParseDir(context.Background(), root, ParseDirOptions{Patterns: []string{"["}}) {
+			aTest.MustBeAnError(err)
+			aTest.MustBeEqual(object, (*DecodedObject)(nil))
+			errorsCount++
+		}
+		aTest.MustBeEqual(errorsCount, 1)
+	}
+
+	// Test #5. Negative: no directory.
+	{
+		var errorsCount = 0
+		for _, err := range ParseDir(context.Background(), filepath.Join(root, "none"), ParseDirOptions{}) {
+			aTest.MustBeAnError(err)
+			errorsCount++
+		}
+		aTest.MustBeEqual(errorsCount, 1)
+	}
+}
+
+func Test_matchesAnyPattern(t *testing.T) {
+	var aTest = tester.New(t)
+
+	aTest.MustBeEqual(matchesAnyPattern("a.torrent", nil), true)
+	aTest.MustBeEqual(matchesAnyPattern("a.torrent", []string{"*.txt", "*.torrent"}), true)
+	aTest.MustBeEqual(matchesAnyPattern("a.torrent.gz", []string{"*.torrent"}), false)
+}
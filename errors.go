@@ -2,12 +2,48 @@ package bencode
 
 // Error messages and formats.
 const (
-	ErrByteStringToInt    = "byte string to integer conversion error"
-	ErrDataType           = "unsupported type"
-	ErrFileNotInitialized = "file is not initialized"
-	ErrHeaderLength       = "the length header is too big: %v"
-	ErrSelfCheck          = "self-check error"
-	ErrTypeAssertion      = "type assertion error"
-	ErrFIntegerLength     = "the integer is too big: %v"
-	ErrFSyntaxErrorAt     = "syntax error at: '%v'"
+	ErrByteStringToInt       = "byte string to integer conversion error"
+	ErrDataType              = "unsupported type"
+	ErrFileIsAlreadyMapped   = "file is already mapped"
+	ErrFileNotInitialized    = "file is not initialized"
+	ErrFilePathIsNotSet      = "file path is not set"
+	ErrHeaderLength          = "the length header is too big: %v"
+	ErrInfoHashLength        = "wrong length"
+	ErrMappedDataCompressed  = "compressed files can not be mapped"
+	ErrMappingIsNotSupported = "memory mapping is not supported on this system"
+	ErrSchemaAlternatives    = "the value does not match any of the alternatives"
+	ErrSchemaDuplicateKey    = "duplicate key"
+	ErrSchemaText            = "the byte string is not a UTF-8 text"
+	ErrSchemaUnknownKey      = "unknown key"
+	ErrSchemaUnsortedKey     = "the key is not in sorted order"
+	ErrSelfCheck             = "self-check error"
+	ErrSkipSubtree           = "skip this subtree"
+	ErrSourceDataIsNotSet    = "source data is not set"
+	ErrTypeAssertion         = "type assertion error"
+	ErrUnreadByte            = "no byte to unread"
+	ErrFCompressionWrite     = "writing of %v compressed data is not supported"
+	ErrFDecompressedSize     = "decompressed data exceeds the limit of %v bytes"
+	ErrFFileIsTooBig         = "the file is too big: %v"
+	ErrFIndexOutOfRange      = "index is out of range: %v"
+	ErrFInfoHash             = "bad info hash %q: %v"
+	ErrFIntegerLength        = "the integer is too big: %v"
+	ErrFIntegerOverflow      = "the integer does not fit into the type: %v"
+	ErrFJSONInteger          = "JSON number is not an integer: %v"
+	ErrFJSONKey              = "bad JSON object key: %v"
+	ErrFJSONTaggedBytes      = "bad tagged byte string at offset: %v"
+	ErrFJSONToken            = "unsupported JSON token: %v"
+	ErrFKeyIsDuplicate       = "duplicate key: %v"
+	ErrFKeyIsNotFound        = "key is not found: %v"
+	ErrFKindMismatch         = "kind mismatch: %v is expected, %v is received"
+	ErrFPathIsNotFound       = "path is not found: %v"
+	ErrFPathSyntax           = "path syntax error at position %v: %v"
+	ErrFSchemaCountRange     = "the number of items is out of range [%v, %v]: %v"
+	ErrFSchemaIntegerRange   = "the integer is out of range [%v, %v]: %v"
+	ErrFSchemaLengthMultiple = "the length is not a multiple of %v: %v"
+	ErrFSchemaLengthRange    = "the length is out of range [%v, %v]: %v"
+	ErrFSchemaMissingKey     = "required key is missing: %v"
+	ErrFSyntaxErrorAt        = "syntax error at: '%v'"
+	ErrFTextSyntax           = "text syntax error at line %v, column %v: %v"
+	ErrFTrailingData         = "trailing data at offset: %v"
+	ErrFTypeMismatch         = "type mismatch: %v is expected, %v is received"
 )
@@ -0,0 +1,108 @@
+package bencode
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"reflect"
+)
+
+// Decode decodes a single 'bencoded' value from the reader and converts it
+// into the T type. T is one of the types produced by the decoder: int64,
+// []byte, []any, []DictionaryItem, Value or any. A byte string may also be
+// requested as a string.
+//
+// A *bufio.Reader is used as is, so that the data following the value stays
+// in it and several values may be decoded one by one. Any other reader is
+// wrapped into a buffered reader which may read ahead, i.e. the data
+// following the value may be consumed.
+func Decode[T any](r io.Reader) (result T, err error) {
+	var reader, ok = r.(*bufio.Reader)
+	if !ok {
+		reader = bufio.NewReader(r)
+	}
+
+	var value any
+	value, err = NewDecoder(reader).Decode()
+	if err != nil {
+		return result, err
+	}
+
+	return convertDecodedValue[T](value)
+}
+
+// DecodeBytes decodes a 'bencoded' byte array into the T type. The array must
+// contain exactly one value, trailing data is an error. See Decode for the
+// list of supported types.
+func DecodeBytes[T any](data []byte) (result T, err error) {
+	var bytesReader = bytes.NewReader(data)
+	var bufioReader = bufio.NewReader(bytesReader)
+
+	var value any
+	value, err = NewDecoder(bufioReader).Decode()
+	if err != nil {
+		return result, err
+	}
+
+	// Check for the trailing data.
+	var bytesLeft = bufioReader.Buffered() + bytesReader.Len()
+	if bytesLeft > 0 {
+		return result, fmt.Errorf(ErrFTrailingData, len(data)-bytesLeft)
+	}
+
+	return convertDecodedValue[T](value)
+}
+
+// Get finds a value by its key in a decoded dictionary and converts it into
+// the T type. See Decode for the list of supported types and Dictionary for
+// the requirements of the lookup.
+func Get[T any](dictionary []DictionaryItem, key string) (result T, err error) {
+	var value, ok = Dictionary(dictionary).Get(key)
+	if !ok {
+		return result, fmt.Errorf(ErrFKeyIsNotFound, key)
+	}
+
+	return convertDecodedValue[T](value)
+}
+
+// At takes a value by its index in a decoded list and converts it into the T
+// type. See Decode for the list of supported types.
+func At[T any](list []any, index int) (result T, err error) {
+	if (index < 0) || (index >= len(list)) {
+		return result, fmt.Errorf(ErrFIndexOutOfRange, index)
+	}
+
+	return convertDecodedValue[T](list[index])
+}
+
+// convertDecodedValue converts a decoded value into the T type.
+func convertDecodedValue[T any](value any) (result T, err error) {
+	var ok bool
+	result, ok = value.(T)
+	if ok {
+		return result, nil
+	}
+
+	// Strongly typed values.
+	var typedValue *Value
+	typedValue, ok = any(&result).(*Value)
+	if ok {
+		*typedValue, err = ToValue(value)
+		return result, err
+	}
+
+	// Byte strings may be requested as text.
+	var ba []byte
+	ba, ok = value.([]byte)
+	if ok {
+		var text *string
+		text, ok = any(&result).(*string)
+		if ok {
+			*text = string(ba)
+			return result, nil
+		}
+	}
+
+	return result, fmt.Errorf(ErrFTypeMismatch, reflect.TypeFor[T](), reflect.TypeOf(value))
+}
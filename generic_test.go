@@ -0,0 +1,130 @@
+package bencode
+
+import (
+	"bufio"
+	"strings"
+	"testing"
+
+	"github.com/vault-thirteen/auxie/tester"
+)
+
+func Test_Decode(t *testing.T) {
+	var aTest = tester.New(t)
+
+	// Test #1. Positive.
+	{
+		result, err := Decode[[]DictionaryItem](strings.NewReader("d4:info3:Sune"))
+		aTest.MustBeNoError(err)
+		aTest.MustBeEqual(len(result), 1)
+		aTest.MustBeEqual(result[0].KeyStr, "info")
+	}
+
+	// Test #2. Negative: type mismatch.
+	{
+		_, err := Decode[int64](strings.NewReader("4:spam"))
+		aTest.MustBeAnError(err)
+	}
+
+	// Test #3. Negative: syntax error.
+	{
+		_, err := Decode[any](strings.NewReader("x"))
+		aTest.MustBeAnError(err)
+	}
+
+	// Test #4. Positive: values one by one from a small buffered reader.
+	{
+		var reader = bufio.NewReaderSize(strings.NewReader("i1e4:spami3e"), 16)
+
+		first, err := Decode[int64](reader)
+		aTest.MustBeNoError(err)
+		aTest.MustBeEqual(first, int64(1))
+
+		second, err := Decode[string](reader)
+		aTest.MustBeNoError(err)
+		aTest.MustBeEqual(second, "spam")
+
+		third, err := Decode[int64](reader)
+		aTest.MustBeNoError(err)
+		aTest.MustBeEqual(third, int64(3))
+	}
+}
+
+func Test_DecodeBytes(t *testing.T) {
+	var aTest = tester.New(t)
+
+	// Test #1. Positive: integer.
+	{
+		result, err := DecodeBytes[int64]([]byte("i-42e"))
+		aTest.MustBeNoError(err)
+		aTest.MustBeEqual(result, int64(-42))
+	}
+
+	// Test #2. Positive: byte string as text.
+	{
+		result, err := DecodeBytes[string]([]byte("4:spam"))
+		aTest.MustBeNoError(err)
+		aTest.MustBeEqual(result, "spam")
+	}
+
+	// Test #3. Negative: trailing data.
+	{
+		_, err := DecodeBytes[int64]([]byte("i1ei2e"))
+		aTest.MustBeAnError(err)
+		aTest.MustBeEqual(err.Error(), "trailing data at offset: 3")
+	}
+}
+
+func Test_Get(t *testing.T) {
+	var aTest = tester.New(t)
+
+	var dictionary = []DictionaryItem{
+		{Key: []byte("length"), Value: int64(7)},
+		{Key: []byte("name"), Value: []byte("file")},
+	}
+
+	// Test #1. Positive.
+	{
+		length, err := Get[int64](dictionary, "length")
+		aTest.MustBeNoError(err)
+		aTest.MustBeEqual(length, int64(7))
+
+		name, err := Get[string](dictionary, "name")
+		aTest.MustBeNoError(err)
+		aTest.MustBeEqual(name, "file")
+	}
+
+	// Test #2. Negative: no key.
+	{
+		_, err := Get[int64](dictionary, "size")
+		aTest.MustBeAnError(err)
+	}
+
+	// Test #3. Negative: type mismatch.
+	{
+		_, err := Get[[]any](dictionary, "name")
+		aTest.MustBeAnError(err)
+		aTest.MustBeEqual(err.Error(), "type mismatch: []interface {} is expected, []uint8 is received")
+	}
+}
+
+func Test_At(t *testing.T) {
+	var aTest = tester.New(t)
+
+	var list = []any{int64(1), []byte("two")}
+
+	// Test #1. Positive.
+	{
+		result, err := At[[]byte](list, 1)
+		aTest.MustBeNoError(err)
+		aTest.MustBeEqual(result, []byte("two"))
+	}
+
+	// Test #2. Negative.
+	{
+		_, err := At[int64](list, 2)
+		aTest.MustBeAnError(err)
+
+		_, err = At[int64](list, -1)
+		aTest.MustBeAnError(err)
+	}
+}
@@ -0,0 +1,188 @@
+package bencode
+
+import (
+	"fmt"
+	"iter"
+)
+
+// Iterators over decoded lists and dictionaries.
+
+// DictionaryItems returns an iterator over keys and values of a decoded
+// dictionary in their order.
+func DictionaryItems(dictionary []DictionaryItem) iter.Seq2[string, any] {
+	return Dictionary(dictionary).All()
+}
+
+// ListItems returns an iterator over the elements of a decoded list converted
+// into the T type. See Decode for the list of supported types. The iteration
+// stops after the first element which can not be converted.
+func ListItems[T any](list []any) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for _, item := range list {
+			var value, err = convertDecodedValue[T](item)
+			if !yield(value, err) || (err != nil) {
+				return
+			}
+		}
+	}
+}
+
+// All returns an iterator over indices and elements of the list.
+func (l List) All() iter.Seq2[int, Value] {
+	return func(yield func(int, Value) bool) {
+		for i, item := range l {
+			if !yield(i, item) {
+				return
+			}
+		}
+	}
+}
+
+// All returns an iterator over keys and values of the dictionary in their
+// order.
+func (d Dict) All() iter.Seq2[string, Value] {
+	return func(yield func(string, Value) bool) {
+		for _, entry := range d {
+			if !yield(string(entry.Key), entry.Value) {
+				return
+			}
+		}
+	}
+}
+
+// Lazy iterators reading elements from the stream.
+
+// StreamList returns an iterator over the elements of a list read from the
+// stream. Each step decodes a single element, so that the whole list is never
+// kept in memory. The iteration stops after the first error. If the loop is
+// left before the end of the list, the rest of the list stays in the stream.
+func (d Decoder) StreamList() iter.Seq2[any, error] {
+	return func(yield func(any, error) bool) {
+		var err = d.readHeader(HeaderList)
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+
+		for {
+			var isEnd bool
+			isEnd, err = probeEnd(d.reader)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			if isEnd {
+				return
+			}
+
+			var listItem any
+			listItem, err = d.readBencodedValue()
+			if !yield(listItem, err) || (err != nil) {
+				return
+			}
+		}
+	}
+}
+
+// StreamDictionary returns an iterator over the items of a dictionary read
+// from the stream. Each step decodes a single item, so that the whole
+// dictionary is never kept in memory. The iteration stops after the first
+// error. If the loop is left before the end of the dictionary, the rest of the
+// dictionary stays in the stream.
+func (d Decoder) StreamDictionary() iter.Seq2[DictionaryItem, error] {
+	return func(yield func(DictionaryItem, error) bool) {
+		var err = d.readHeader(HeaderDictionary)
+		if err != nil {
+			yield(DictionaryItem{}, err)
+			return
+		}
+
+		for {
+			var isEnd bool
+			isEnd, err = probeEnd(d.reader)
+			if err != nil {
+				yield(DictionaryItem{}, err)
+				return
+			}
+			if isEnd {
+				return
+			}
+
+			var item DictionaryItem
+			item, err = d.readDictionaryItem()
+			if !yield(item, err) || (err != nil) {
+				return
+			}
+		}
+	}
+}
+
+// SeekDictionaryKey reads a dictionary from the stream until the key is found.
+// Values of other keys are skipped without being decoded. After a successful
+// search the stream is positioned at the value of the key, so that it can be
+// read by Decode, StreamList or StreamDictionary.
+func (d Decoder) SeekDictionaryKey(key string) (err error) {
+	err = d.readHeader(HeaderDictionary)
+	if err != nil {
+		return err
+	}
+
+	for {
+		var isEnd bool
+		isEnd, err = probeEnd(d.reader)
+		if err != nil {
+			return err
+		}
+		if isEnd {
+			return fmt.Errorf(ErrFKeyIsNotFound, key)
+		}
+
+		var dictKey []byte
+		dictKey, err = d.readDictionaryKey()
+		if err != nil {
+			return err
+		}
+
+		if string(dictKey) == key {
+			return nil
+		}
+
+		err = d.skipBencodedValue()
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// readHeader reads the header of a value and checks it.
+func (d Decoder) readHeader(header byte) (err error) {
+	var b byte
+	b, err = d.reader.ReadByte()
+	if err != nil {
+		return err
+	}
+
+	if b != header {
+		return fmt.Errorf(ErrFSyntaxErrorAt, []byte{b})
+	}
+
+	return nil
+}
+
+// readDictionaryItem reads a key and a value of a dictionary.
+func (d Decoder) readDictionaryItem() (item DictionaryItem, err error) {
+	item.Key, err = d.readDictionaryKey()
+	if err != nil {
+		return DictionaryItem{}, err
+	}
+
+	item.Value, err = d.readDictionaryValue()
+	if err != nil {
+		return DictionaryItem{}, err
+	}
+
+	item.KeyStr = string(item.Key)
+	item.ValueStr = convertInterfaceToString(item.Value)
+
+	return item, nil
+}
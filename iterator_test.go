@@ -0,0 +1,165 @@
+package bencode
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/vault-thirteen/auxie/tester"
+)
+
+func newTestDecoder(s string) *Decoder {
+	return NewDecoder(bufio.NewReader(strings.NewReader(s)))
+}
+
+func Test_DictionaryItems(t *testing.T) {
+	var aTest = tester.New(t)
+
+	var keys []string
+	for key := range DictionaryItems([]DictionaryItem{{Key: []byte("a")}, {Key: []byte("b")}}) {
+		keys = append(keys, key)
+	}
+	aTest.MustBeEqual(keys, []string{"a", "b"})
+}
+
+func Test_ListItems(t *testing.T) {
+	var aTest = tester.New(t)
+
+	// Test #1. Positive.
+	{
+		var sum int64
+		for value, err := range ListItems[int64]([]any{int64(1), int64(2)}) {
+			aTest.MustBeNoError(err)
+			sum += value
+		}
+		aTest.MustBeEqual(sum, int64(3))
+	}
+
+	// Test #2. Negative.
+	{
+		var errorsCount int
+		for _, err := range ListItems[int64]([]any{[]byte("x"), int64(2)}) {
+			aTest.MustBeAnError(err)
+			errorsCount++
+		}
+		aTest.MustBeEqual(errorsCount, 1)
+	}
+}
+
+func Test_List_All(t *testing.T) {
+	var aTest = tester.New(t)
+
+	var indices []int
+	for i := range (List{Int(1), Int(2), Int(3)}).All() {
+		if i == 2 {
+			break
+		}
+		indices = append(indices, i)
+	}
+	aTest.MustBeEqual(indices, []int{0, 1})
+}
+
+func Test_Dict_All(t *testing.T) {
+	var aTest = tester.New(t)
+
+	var result = make(map[string]Value)
+	for key, value := range (Dict{{Key: []byte("a"), Value: Int(1)}}).All() {
+		result[key] = value
+	}
+	aTest.MustBeEqual(result, map[string]Value{"a": Int(1)})
+}
+
+func Test_Decoder_StreamList(t *testing.T) {
+	var aTest = tester.New(t)
+
+	// Test #1. Positive.
+	{
+		var items []any
+		for item, err := range newTestDecoder("li1e3:abclee").StreamList() {
+			aTest.MustBeNoError(err)
+			items = append(items, item)
+		}
+		aTest.MustBeEqual(items, []any{int64(1), []byte("abc"), []any{}})
+	}
+
+	// Test #2. Negative: not a list.
+	{
+		var errs []error
+		for _, err := range newTestDecoder("i1e").StreamList() {
+			errs = append(errs, err)
+		}
+		aTest.MustBeEqual(len(errs), 1)
+		aTest.MustBeAnError(errs[0])
+	}
+
+	// Test #3. Negative: broken element.
+	{
+		var errs []error
+		for _, err := range newTestDecoder("li1ei").StreamList() {
+			errs = append(errs, err)
+		}
+		aTest.MustBeEqual(len(errs), 2)
+		aTest.MustBeNoError(errs[0])
+		aTest.MustBeAnError(errs[1])
+	}
+}
+
+func Test_Decoder_StreamDictionary(t *testing.T) {
+	var aTest = tester.New(t)
+
+	// Test #1. Positive.
+	{
+		var items []DictionaryItem
+		for item, err := range newTestDecoder("d1:ai1e1:b2:xye").StreamDictionary() {
+			aTest.MustBeNoError(err)
+			items = append(items, item)
+		}
+		aTest.MustBeEqual(items, []DictionaryItem{
+			{Key: []byte("a"), Value: int64(1), KeyStr: "a"},
+			{Key: []byte("b"), Value: []byte("xy"), KeyStr: "b", ValueStr: "xy"},
+		})
+	}
+
+	// Test #2. Negative: unexpected end.
+	{
+		var lastError error
+		for _, err := range newTestDecoder("d1:a").StreamDictionary() {
+			lastError = err
+		}
+		aTest.MustBeEqual(lastError, io.EOF)
+	}
+}
+
+func Test_Decoder_SeekDictionaryKey(t *testing.T) {
+	var aTest = tester.New(t)
+
+	// Test #1. Positive: a scrape response.
+	{
+		var decoder = newTestDecoder("d5:flagsd3:abcli1eee5:filesd2:h1d8:completei5ee2:h2d8:completei7eeee")
+		err := decoder.SeekDictionaryKey("files")
+		aTest.MustBeNoError(err)
+
+		var hashes []string
+		for item, err := range decoder.StreamDictionary() {
+			aTest.MustBeNoError(err)
+			hashes = append(hashes, item.KeyStr)
+		}
+		aTest.MustBeEqual(hashes, []string{"h1", "h2"})
+	}
+
+	// Test #2. Negative: no key.
+	{
+		err := newTestDecoder("d1:ai1ee").SeekDictionaryKey("files")
+		aTest.MustBeAnError(err)
+	}
+
+	// Test #3. Negative: broken value being skipped.
+	{
+		err := newTestDecoder("d1:a5:abe").SeekDictionaryKey("files")
+		aTest.MustBeAnError(err)
+
+		err = newTestDecoder("d1:ax").SeekDictionaryKey("files")
+		aTest.MustBeAnError(err)
+	}
+}
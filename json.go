@@ -0,0 +1,323 @@
+package bencode
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Lossless conversion between 'bencode' and JSON.
+//
+// Integers are JSON numbers. Byte strings which are valid UTF-8 texts are JSON
+// strings, other byte strings are objects with a single key:
+// {"$bytes":"<base64>"}. Lists are arrays. Dictionaries are objects with the
+// keys in their original order. Keys which are not valid UTF-8 texts are
+// written as "$base64:<base64>"; keys starting with the '$' symbol are
+// escaped by doubling the symbol, so that the tags never clash with real keys.
+// Base64 uses the standard alphabet with padding.
+
+// JSON tags.
+const (
+	JSONTagPrefix    = "$"
+	JSONTagBytes     = "$bytes"
+	JSONTagKeyBase64 = "$base64:"
+)
+
+// ToJSON converts a value into JSON. The value may be given in any form
+// accepted by ToValue.
+func ToJSON(v any) (data []byte, err error) {
+	var value Value
+	value, err = ToValue(v)
+	if err != nil {
+		return nil, err
+	}
+
+	return appendJSONValue(nil, value)
+}
+
+// BencodeToJSON converts a 'bencoded' document into JSON.
+func BencodeToJSON(data []byte) (jsonData []byte, err error) {
+	var value Value
+	value, err = DecodeBytes[Value](data)
+	if err != nil {
+		return nil, err
+	}
+
+	return appendJSONValue(nil, value)
+}
+
+// FromJSON converts JSON into a value in the form returned by the decoder.
+// See the description of the format above.
+func FromJSON(data []byte) (result any, err error) {
+	var decoder = json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	result, err = readJSONValue(decoder)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check for the trailing data.
+	_, err = decoder.Token()
+	if !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf(ErrFTrailingData, decoder.InputOffset())
+	}
+
+	return result, nil
+}
+
+// JSONToBencode converts JSON into a 'bencoded' document.
+func JSONToBencode(jsonData []byte) (data []byte, err error) {
+	var value any
+	value, err = FromJSON(jsonData)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewEncoder().EncodeAnInterface(value)
+}
+
+// appendJSONValue appends a value in JSON form to the byte array.
+func appendJSONValue(dst []byte, v Value) (result []byte, err error) {
+	switch x := v.(type) {
+	case Int:
+		return strconv.AppendInt(dst, int64(x), 10), nil
+
+	case String:
+		return appendJSONByteString(dst, x), nil
+
+	case List:
+		result = append(dst, '[')
+		for i, item := range x {
+			if i > 0 {
+				result = append(result, ',')
+			}
+
+			result, err = appendJSONValue(result, item)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return append(result, ']'), nil
+
+	case Dict:
+		result = append(dst, '{')
+		for i, entry := range x {
+			if i > 0 {
+				result = append(result, ',')
+			}
+
+			result = appendJSONString(result, encodeJSONKey(entry.Key))
+			result = append(result, ':')
+			result, err = appendJSONValue(result, entry.Value)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return append(result, '}'), nil
+	}
+
+	return nil, errors.New(ErrDataType)
+}
+
+// appendJSONByteString appends a byte string in JSON form to the byte array.
+func appendJSONByteString(dst []byte, ba []byte) []byte {
+	if utf8.Valid(ba) {
+		return appendJSONString(dst, string(ba))
+	}
+
+	dst = append(dst, `{"`+JSONTagBytes+`":"`...)
+	dst = base64.StdEncoding.AppendEncode(dst, ba)
+	return append(dst, `"}`...)
+}
+
+// encodeJSONKey converts a dictionary key into a JSON object key.
+func encodeJSONKey(key []byte) string {
+	if !utf8.Valid(key) {
+		return JSONTagKeyBase64 + base64.StdEncoding.EncodeToString(key)
+	}
+
+	if strings.HasPrefix(string(key), JSONTagPrefix) {
+		return JSONTagPrefix + string(key)
+	}
+
+	return string(key)
+}
+
+// decodeJSONKey converts a JSON object key into a dictionary key.
+func decodeJSONKey(key string) (result []byte, err error) {
+	if !strings.HasPrefix(key, JSONTagPrefix) {
+		return []byte(key), nil
+	}
+
+	if strings.HasPrefix(key, JSONTagPrefix+JSONTagPrefix) {
+		return []byte(key[len(JSONTagPrefix):]), nil
+	}
+
+	if strings.HasPrefix(key, JSONTagKeyBase64) {
+		result, err = base64.StdEncoding.DecodeString(key[len(JSONTagKeyBase64):])
+		if err == nil {
+			return result, nil
+		}
+	}
+
+	return nil, fmt.Errorf(ErrFJSONKey, key)
+}
+
+// appendJSONString appends a valid UTF-8 text as a JSON string to the byte
+// array.
+func appendJSONString(dst []byte, s string) []byte {
+	const hexDigits = "0123456789abcdef"
+
+	dst = append(dst, '"')
+	for i := 0; i < len(s); i++ {
+		var b = s[i]
+		switch {
+		case (b == '"') || (b == '\\'):
+			dst = append(dst, '\\', b)
+		case b == '\n':
+			dst = append(dst, '\\', 'n')
+		case b == '\r':
+			dst = append(dst, '\\', 'r')
+		case b == '\t':
+			dst = append(dst, '\\', 't')
+		case b < ' ':
+			dst = append(dst, '\\', 'u', '0', '0', hexDigits[b>>4], hexDigits[b&0xF])
+		default:
+			dst = append(dst, b)
+		}
+	}
+
+	return append(dst, '"')
+}
+
+// readJSONValue reads a value from the JSON decoder.
+func readJSONValue(decoder *json.Decoder) (result any, err error) {
+	var token json.Token
+	token, err = decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	switch x := token.(type) {
+	case json.Number:
+		var i int64
+		i, err = strconv.ParseInt(x.String(), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf(ErrFJSONInteger, x)
+		}
+		return i, nil
+
+	case string:
+		return []byte(x), nil
+
+	case json.Delim:
+		switch x {
+		case '[':
+			return readJSONArray(decoder)
+		case '{':
+			return readJSONObject(decoder)
+		}
+	}
+
+	return nil, fmt.Errorf(ErrFJSONToken, token)
+}
+
+// readJSONArray reads the elements of a JSON array. We suppose that the start
+// of the array has already been read.
+func readJSONArray(decoder *json.Decoder) (list []any, err error) {
+	list = make([]any, 0)
+	for decoder.More() {
+		var item any
+		item, err = readJSONValue(decoder)
+		if err != nil {
+			return nil, err
+		}
+
+		list = append(list, item)
+	}
+
+	// The end of the array.
+	_, err = decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+// readJSONObject reads the items of a JSON object, which is either a
+// dictionary or a tagged byte string. We suppose that the start of the object
+// has already been read.
+func readJSONObject(decoder *json.Decoder) (result any, err error) {
+	var dictionary = make([]DictionaryItem, 0)
+	for decoder.More() {
+		var token json.Token
+		token, err = decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+
+		var key = token.(string)
+		if (key == JSONTagBytes) && (len(dictionary) == 0) {
+			return readJSONTaggedBytes(decoder)
+		}
+
+		var item DictionaryItem
+		item.Key, err = decodeJSONKey(key)
+		if err != nil {
+			return nil, err
+		}
+
+		item.Value, err = readJSONValue(decoder)
+		if err != nil {
+			return nil, err
+		}
+
+		item.KeyStr = string(item.Key)
+		item.ValueStr = convertInterfaceToString(item.Value)
+		dictionary = append(dictionary, item)
+	}
+
+	// The end of the object.
+	_, err = decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	return dictionary, nil
+}
+
+// readJSONTaggedBytes reads a byte string tagged with the '$bytes' key. We
+// suppose that the key has already been read.
+func readJSONTaggedBytes(decoder *json.Decoder) (ba []byte, err error) {
+	var token json.Token
+	token, err = decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	var text, ok = token.(string)
+	if !ok || decoder.More() {
+		return nil, fmt.Errorf(ErrFJSONTaggedBytes, decoder.InputOffset())
+	}
+
+	ba, err = base64.StdEncoding.DecodeString(text)
+	if err != nil {
+		return nil, fmt.Errorf(ErrFJSONTaggedBytes, decoder.InputOffset())
+	}
+
+	// The end of the object.
+	_, err = decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	return ba, nil
+}
@@ -0,0 +1,89 @@
+package bencode
+
+import (
+	"testing"
+	"time"
+
+	"github.com/vault-thirteen/auxie/tester"
+)
+
+func Test_ToJSON(t *testing.T) {
+	var aTest = tester.New(t)
+
+	// Test #1. Positive.
+	{
+		data, err := ToJSON(Dict{
+			{Key: []byte("z"), Value: Int(-9007199254740993)},
+			{Key: []byte("a"), Value: List{String("text \"q\"\n<&>"), String{0xFF, 0x00}}},
+			{Key: []byte("$bytes"), Value: String("x")},
+			{Key: []byte{0xAB}, Value: Dict{}},
+			{Key: []byte("c"), Value: String{0x01}},
+		})
+		aTest.MustBeNoError(err)
+		aTest.MustBeEqual(string(data),
+			`{"z":-9007199254740993,"a":["text \"q\"\n<&>",{"$bytes":"/wA="}],"$$bytes":"x","$base64:qw==":{},"c":"\u0001"}`,
+		)
+	}
+
+	// Test #2. Negative.
+	{
+		_, err := ToJSON(time.Time{})
+		aTest.MustBeAnError(err)
+
+		_, err = ToJSON(List{nil})
+		aTest.MustBeAnError(err)
+	}
+}
+
+func Test_FromJSON(t *testing.T) {
+	var aTest = tester.New(t)
+
+	// Test #1. Positive.
+	{
+		result, err := FromJSON([]byte(`{"z":-9007199254740993,"a":["t",{"$bytes":"/wA="}],"$$bytes":"x","$base64:qw==":{}}`))
+		aTest.MustBeNoError(err)
+		aTest.MustBeEqual(result, []DictionaryItem{
+			{Key: []byte("z"), Value: int64(-9007199254740993), KeyStr: "z"},
+			{Key: []byte("a"), Value: []any{[]byte("t"), []byte{0xFF, 0x00}}, KeyStr: "a"},
+			{Key: []byte("$bytes"), Value: []byte("x"), KeyStr: "$bytes", ValueStr: "x"},
+			{Key: []byte{0xAB}, Value: []DictionaryItem{}, KeyStr: "\xab"},
+		})
+	}
+
+	// Test #2. Negative.
+	{
+		for _, data := range []string{
+			``, `1.5`, `true`, `null`, `1 2`, `[1`, `{"a":1`, `{"a":}`,
+			`{"$bytes":1}`, `{"$bytes":"!"}`, `{"$bytes":"","a":1}`, `{"$bytes":""`,
+			`{"$x":1}`, `{"$base64:!":1}`, `[{"$bytes"`,
+		} {
+			_, err := FromJSON([]byte(data))
+			aTest.MustBeAnError(err)
+		}
+	}
+}
+
+func Test_BencodeToJSON(t *testing.T) {
+	var aTest = tester.New(t)
+
+	// Test #1. Round trip.
+	{
+		var source = "d4:infod6:lengthi12e6:pieces3:\x00\x01\xffe4:listli1e0:leee"
+		data, err := BencodeToJSON([]byte(source))
+		aTest.MustBeNoError(err)
+		aTest.MustBeEqual(string(data), `{"info":{"length":12,"pieces":{"$bytes":"AAH/"}},"list":[1,"",[]]}`)
+
+		encoded, err := JSONToBencode(data)
+		aTest.MustBeNoError(err)
+		aTest.MustBeEqual(string(encoded), source)
+	}
+
+	// Test #2. Negative.
+	{
+		_, err := BencodeToJSON([]byte("x"))
+		aTest.MustBeAnError(err)
+
+		_, err = JSONToBencode([]byte("x"))
+		aTest.MustBeAnError(err)
+	}
+}
@@ -0,0 +1,94 @@
+package bencode
+
+import (
+	"errors"
+	"io"
+	"time"
+)
+
+// sliceSource is a source of data reading a byte slice. Byte strings read
+// from it are not copied: they point into the data. It is used to decode
+// memory-mapped files, so the byte strings are valid only as long as the
+// data is. The string fields of dictionary items are copies, as strings
+// must never change.
+type sliceSource struct {
+	data []byte
+	pos  int
+}
+
+// newSliceSource is the constructor of a byte slice source.
+func newSliceSource(data []byte) (ss *sliceSource) {
+	return &sliceSource{
+		data: data,
+	}
+}
+
+// ReadByte reads the next byte of the data.
+func (ss *sliceSource) ReadByte() (b byte, err error) {
+	if ss.pos >= len(ss.data) {
+		return 0, io.EOF
+	}
+
+	b = ss.data[ss.pos]
+	ss.pos++
+
+	return b, nil
+}
+
+// UnreadByte returns the last read byte back.
+func (ss *sliceSource) UnreadByte() (err error) {
+	if ss.pos <= 0 {
+		return errors.New(ErrUnreadByte)
+	}
+
+	ss.pos--
+
+	return nil
+}
+
+// readBytes reads the contents of a byte string. The returned slice points
+// into the data and its capacity is limited, so that appending to it never
+// touches the data.
+func (ss *sliceSource) readBytes(n uint) (ba []byte, err error) {
+	err = ss.discardBytes(n)
+	if err != nil {
+		return nil, err
+	}
+
+	return ss.data[ss.pos-int(n) : ss.pos : ss.pos], nil
+}
+
+// discardBytes skips the contents of a byte string.
+func (ss *sliceSource) discardBytes(n uint) (err error) {
+
+	// The data ends before the byte string does.
+	if uint64(n) > uint64(len(ss.data)-ss.pos) {
+		ss.pos = len(ss.data)
+		return io.EOF
+	}
+
+	ss.pos += int(n)
+
+	return nil
+}
+
+// parseMappedData parses the data encoded with 'bencode' encoding into an
+// object without copying byte strings. The object keeps the data as its
+// source data.
+func parseMappedData(filePath string, data []byte, options ParseOptions) (result *DecodedObject, err error) {
+	var decodeStartTime = time.Now()
+
+	var src = newSliceSource(data)
+	var ifc any
+	ifc, err = readBencodedValue(src)
+	if err != nil {
+		return nil, err
+	}
+
+	err = checkTrailingData(len(data), len(data)-src.pos, options)
+	if err != nil {
+		return nil, err
+	}
+
+	return newDecodedObject(filePath, data, ifc, decodeStartTime, options)
+}
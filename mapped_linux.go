@@ -0,0 +1,110 @@
+//go:build linux
+
+package bencode
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"syscall"
+
+	ae "github.com/vault-thirteen/auxie/errors"
+)
+
+// ParseMapped parses an input file mapped into the memory. Byte strings of
+// the decoded object are not copied, they point into the mapping, so that
+// big files are not copied onto the heap. The mapping lives until the file
+// is closed with the Close method; the decoded object, its byte strings and
+// its source data must not be used after that. The string fields of
+// dictionary items are copies and stay valid. The file must not be truncated
+// while it is mapped.
+// If 'makeSelfCheck' flag is enabled, the self check is performed after
+// decoding.
+func (f *File) ParseMapped(makeSelfCheck bool) (result *DecodedObject, err error) {
+	return f.ParseMappedWithOptions(ParseOptions{MakeSelfCheck: makeSelfCheck})
+}
+
+// ParseMappedWithOptions parses an input file mapped into the memory using
+// the options. See ParseMapped for details.
+func (f *File) ParseMappedWithOptions(options ParseOptions) (result *DecodedObject, err error) {
+
+	// Fool check.
+	if f.mapping != nil {
+		return nil, errors.New(ErrFileIsAlreadyMapped)
+	}
+
+	// Open the file. The mapping outlives the descriptor.
+	err = f.open()
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		// Close the file.
+		derr := f.close()
+		if derr != nil {
+			err = ae.Combine(err, derr)
+		}
+	}()
+
+	var fileInfo os.FileInfo
+	fileInfo, err = f.osFile.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	err = f.mmap(fileInfo.Size())
+	if err != nil {
+		return nil, err
+	}
+
+	// Compressed data can not be decoded without copying.
+	if DetectCompression(f.mapping) != CompressionNone {
+		err = errors.New(ErrMappedDataCompressed)
+	} else {
+		result, err = parseMappedData(f.path, f.mapping, options)
+	}
+	if err != nil {
+		derr := f.unmap()
+		if derr != nil {
+			err = ae.Combine(err, derr)
+		}
+
+		return nil, err
+	}
+
+	result.FileModTime = fileInfo.ModTime()
+
+	return result, nil
+}
+
+// mmap maps the opened file into the memory for reading. Empty files are
+// not mapped.
+func (f *File) mmap(fileSize int64) (err error) {
+	if fileSize == 0 {
+		return nil
+	}
+
+	if int64(int(fileSize)) != fileSize {
+		return fmt.Errorf(ErrFFileIsTooBig, fileSize)
+	}
+
+	f.mapping, err = syscall.Mmap(int(f.osFile.Fd()), 0, int(fileSize), syscall.PROT_READ, syscall.MAP_SHARED)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// unmap releases the memory mapping of the file.
+func (f *File) unmap() (err error) {
+	if f.mapping == nil {
+		return nil
+	}
+
+	err = syscall.Munmap(f.mapping)
+	f.mapping = nil
+
+	return err
+}
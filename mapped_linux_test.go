@@ -0,0 +1,102 @@
+//go:build linux
+
+package bencode
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/vault-thirteen/auxie/tester"
+)
+
+func Test_File_ParseMapped(t *testing.T) {
+	var aTest = tester.New(t)
+
+	// Test Initialization.
+	createTestFolder(t)
+	createTestFileB(t)
+	filePath := filepath.Join(TestFolder, TestFileBName)
+	var f = NewFile(filePath)
+
+	// Test Finalization.
+	defer func() {
+		deleteTestFolder(t)
+	}()
+
+	var do *DecodedObject
+	var err error
+
+	// Test #1. Positive.
+	{
+		do, err = f.ParseMapped(true)
+		aTest.MustBeNoError(err)
+		aTest.MustBeEqual(do.FilePath, filePath)
+		aTest.MustBeEqual(do.RawObject, []DictionaryItem{
+			{
+				Key:      []byte("info"),
+				Value:    []byte("Sun"),
+				KeyStr:   "info",
+				ValueStr: "Sun",
+			},
+		})
+		aTest.MustBeEqual(do.IsSelfChecked, true)
+		aTest.MustBeEqual(&do.SourceData[0] == &f.mapping[0], true)
+
+		// The file is mapped only once.
+		_, err = f.ParseMapped(false)
+		aTest.MustBeAnError(err)
+
+		err = f.Close()
+		aTest.MustBeNoError(err)
+		aTest.MustBeEqual(f.mapping, []byte(nil))
+
+		err = f.Close()
+		aTest.MustBeNoError(err)
+	}
+
+	// Test #2. Negative: the mapping is released on error.
+	{
+		err = os.WriteFile(filePath, []byte("i1ei2e"), 0644)
+		aTest.MustBeNoError(err)
+
+		_, err = f.ParseMapped(true)
+		aTest.MustBeAnError(err)
+		aTest.MustBeEqual(f.mapping, []byte(nil))
+	}
+
+	// Test #3. Negative: empty file.
+	{
+		err = os.WriteFile(filePath, []byte{}, 0644)
+		aTest.MustBeNoError(err)
+
+		_, err = f.ParseMapped(false)
+		aTest.MustBeAnError(err)
+	}
+
+	// Test #4. Negative: no file.
+	{
+		_, err = NewFile(filepath.Join(TestFolder, TestFileCName)).ParseMapped(false)
+		aTest.MustBeAnError(err)
+	}
+}
+
+func Test_File_ParseMapped_compressed(t *testing.T) {
+	var aTest = tester.New(t)
+
+	// Test Initialization.
+	createTestFolder(t)
+	var filePath = filepath.Join(TestFolder, TestFileBName+".gz")
+	err := os.WriteFile(filePath, compressTestData(t, CompressionGzip, []byte(TestFileBContents)), 0644)
+	aTest.MustBeNoError(err)
+
+	// Test Finalization.
+	defer func() {
+		deleteTestFolder(t)
+	}()
+
+	var f = NewFile(filePath)
+	_, err = f.ParseMapped(false)
+	aTest.MustBeAnError(err)
+	aTest.MustBeEqual(f.mapping, []byte(nil))
+}
@@ -0,0 +1,25 @@
+//go:build !linux
+
+package bencode
+
+import (
+	"errors"
+)
+
+// ParseMapped parses an input file mapped into the memory. Memory mapping is
+// supported only on Linux, so an error is returned on other systems.
+func (f *File) ParseMapped(makeSelfCheck bool) (result *DecodedObject, err error) {
+	return f.ParseMappedWithOptions(ParseOptions{MakeSelfCheck: makeSelfCheck})
+}
+
+// ParseMappedWithOptions parses an input file mapped into the memory using
+// the options. Memory mapping is supported only on Linux, so an error is
+// returned on other systems.
+func (f *File) ParseMappedWithOptions(options ParseOptions) (result *DecodedObject, err error) {
+	return nil, errors.New(ErrMappingIsNotSupported)
+}
+
+// unmap releases the memory mapping of the file.
+func (f *File) unmap() (err error) {
+	return nil
+}
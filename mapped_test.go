@@ -0,0 +1,143 @@
+package bencode
+
+import (
+	"bufio"
+	"bytes"
+	"testing"
+
+	"github.com/vault-thirteen/auxie/tester"
+)
+
+func Test_sliceSource_readBencodedValue(t *testing.T) {
+	var aTest = tester.New(t)
+
+	// The results must be the same as the results of the stream source.
+	var inputs = []string{
+		"i123e",
+		"i-5e",
+		"i0e",
+		"0:",
+		"3:abc",
+		"le",
+		"de",
+		"li1e3:abcli2eee",
+		"d4:info3:Sun4:listli1eee",
+		"d1:ad1:bd1:ci1eeee",
+
+		// Errors.
+		"",
+		"x",
+		"i",
+		"ie",
+		"i12",
+		"i1x2e",
+		"i123456789012345678901e",
+		"i99999999999999999999e",
+		"3:ab",
+		"3",
+		"3x:abc",
+		"123456789012345678901:a",
+		"99999999999999999999:a",
+		"l",
+		"li1e",
+		"d",
+		"d3:abc",
+		"di1ei2ee",
+		"d:e",
+	}
+
+	for _, input := range inputs {
+		var expected, expectedErr = NewDecoder(bufio.NewReader(bytes.NewReader([]byte(input)))).readBencodedValue()
+		var result, err = readBencodedValue(newSliceSource([]byte(input)))
+
+		if expectedErr != nil {
+			aTest.MustBeAnError(err)
+			aTest.MustBeEqual(err.Error(), expectedErr.Error())
+			continue
+		}
+
+		aTest.MustBeNoError(err)
+		aTest.MustBeEqual(result, expected)
+	}
+}
+
+func Test_sliceSource_skipBencodedValue(t *testing.T) {
+	var aTest = tester.New(t)
+
+	// Test #1. Positive.
+	for _, input := range []string{"i1e", "3:abc", "le", "li1el3:abcee", "d1:ad1:bi1eee"} {
+		var src = newSliceSource([]byte(input + "tail"))
+		err := skipBencodedValue(src)
+		aTest.MustBeNoError(err)
+		aTest.MustBeEqual(src.pos, len(input))
+	}
+
+	// Test #2. Negative.
+	for _, input := range []string{"", "x", "i1", "4:abc", "l", "li1e", "d1:a", "di1ei1ee"} {
+		err := skipBencodedValue(newSliceSource([]byte(input)))
+		aTest.MustBeAnError(err)
+	}
+}
+
+func Test_sliceSource_UnreadByte(t *testing.T) {
+	var aTest = tester.New(t)
+
+	var src = newSliceSource([]byte("ab"))
+	aTest.MustBeAnError(src.UnreadByte())
+
+	b, err := src.ReadByte()
+	aTest.MustBeNoError(err)
+	aTest.MustBeEqual(b, byte('a'))
+	aTest.MustBeNoError(src.UnreadByte())
+
+	b, err = src.ReadByte()
+	aTest.MustBeNoError(err)
+	aTest.MustBeEqual(b, byte('a'))
+}
+
+func Test_sliceSource_noCopy(t *testing.T) {
+	var aTest = tester.New(t)
+
+	var data = []byte("d4:info3:Sune")
+	var result, err = readBencodedValue(newSliceSource(data))
+	aTest.MustBeNoError(err)
+
+	var item = result.([]DictionaryItem)[0]
+	var value = item.Value.([]byte)
+	aTest.MustBeEqual(&value[0] == &data[9], true)
+	aTest.MustBeEqual(cap(value), 3)
+	aTest.MustBeEqual(item.ValueStr, "Sun")
+
+	// Appending does not change the data.
+	_ = append(value, 'X')
+	aTest.MustBeEqual(string(data), "d4:info3:Sune")
+
+	// Strings are copies, unlike byte strings.
+	data[9] = 'R'
+	aTest.MustBeEqual(string(value), "Run")
+	aTest.MustBeEqual(item.ValueStr, "Sun")
+	aTest.MustBeEqual(item.KeyStr, "info")
+}
+
+func Test_parseMappedData(t *testing.T) {
+	var aTest = tester.New(t)
+
+	// Test #1. Positive.
+	{
+		var data = []byte("d4:info3:Sune")
+		do, err := parseMappedData("path", data, ParseOptions{MakeSelfCheck: true})
+		aTest.MustBeNoError(err)
+		aTest.MustBeEqual(do.FilePath, "path")
+		aTest.MustBeEqual(do.IsSelfChecked, true)
+	}
+
+	// Test #2. Negative.
+	{
+		_, err := parseMappedData("path", []byte("i1ei2e"), ParseOptions{MakeSelfCheck: true})
+		aTest.MustBeAnError(err)
+
+		_, err = parseMappedData("path", []byte("i1ei2e"), ParseOptions{})
+		aTest.MustBeAnError(err)
+		aTest.MustBeEqual(err.Error(), "trailing data at offset: 3")
+	}
+}
@@ -0,0 +1,121 @@
+package metainfo
+
+import (
+	"crypto/sha1"
+	"fmt"
+	"strings"
+
+	"github.com/vault-thirteen/bencode"
+)
+
+// PieceHashSize is the size of a hash sum of a piece.
+const PieceHashSize = sha1.Size
+
+// Info is the info dictionary of a metainfo file. A single-file torrent has
+// the length set, a multi-file torrent has the list of files; the name is
+// the name of the file or of the directory respectively. Pieces are the
+// concatenated SHA-1 hash sums of all the pieces.
+type Info struct {
+	Name        string
+	PieceLength int64
+	Pieces      []byte
+	Length      int64
+	Files       []File
+	Private     bool
+}
+
+// File is a file of a multi-file torrent. Path is the list of path elements,
+// the last one is the name of the file.
+type File struct {
+	Length int64
+	Path   []string
+}
+
+// IsMultiFile checks whether the torrent has a list of files.
+func (i *Info) IsMultiFile() bool {
+	return len(i.Files) > 0
+}
+
+// TotalLength returns the total length of all the files.
+func (i *Info) TotalLength() (length int64) {
+	if !i.IsMultiFile() {
+		return i.Length
+	}
+
+	for _, file := range i.Files {
+		length += file.Length
+	}
+
+	return length
+}
+
+// PieceCount returns the number of pieces.
+func (i *Info) PieceCount() int {
+	return len(i.Pieces) / PieceHashSize
+}
+
+// PieceHash returns the hash sum of the piece with the index. The index must
+// be less than the number of pieces.
+func (i *Info) PieceHash(index int) []byte {
+	return i.Pieces[index*PieceHashSize : (index+1)*PieceHashSize]
+}
+
+// newInfo converts a validated info dictionary.
+func newInfo(info bencode.Dict) (i Info) {
+	i = Info{
+		Name:        getString(info, KeyName),
+		PieceLength: getInt(info, KeyPieceLength),
+		Pieces:      getBytes(info, KeyPieces),
+		Length:      getInt(info, KeyLength),
+		Private:     getInt(info, KeyPrivate) == 1,
+	}
+
+	for _, item := range asList(lookup(info, KeyFiles)) {
+		var file, _ = item.AsDict()
+		i.Files = append(i.Files, File{
+			Length: getInt(file, KeyLength),
+			Path:   toStrings(asList(lookup(file, KeyPath))),
+		})
+	}
+
+	return i
+}
+
+// checkInfo finds the problems of an info dictionary which are not described
+// by the schema: both the length and the list of files, and names and path
+// elements which may lead outside of the directory of the torrent.
+func checkInfo(info bencode.Dict) (problems bencode.ValidationErrors) {
+	var infoPath = KeyInfo
+	var name = getString(info, KeyName)
+	if !isSafePathElement(name) {
+		problems = append(problems, bencode.ValidationError{
+			Path:    infoPath + "." + KeyName,
+			Message: fmt.Sprintf(ErrFPathElement, name),
+		})
+	}
+
+	if (lookup(info, KeyLength) != nil) && (lookup(info, KeyFiles) != nil) {
+		problems = append(problems, bencode.ValidationError{Path: infoPath, Message: ErrLengthAndFiles})
+	}
+
+	for i, item := range asList(lookup(info, KeyFiles)) {
+		var file, _ = item.AsDict()
+		for j, element := range toStrings(asList(lookup(file, KeyPath))) {
+			if isSafePathElement(element) {
+				continue
+			}
+
+			problems = append(problems, bencode.ValidationError{
+				Path:    fmt.Sprintf("%s.%s[%d].%s[%d]", infoPath, KeyFiles, i, KeyPath, j),
+				Message: fmt.Sprintf(ErrFPathElement, element),
+			})
+		}
+	}
+
+	return problems
+}
+
+// isSafePathElement checks whether the path element is a plain name.
+func isSafePathElement(element string) bool {
+	return (len(element) > 0) && (element != ".") && (element != "..") && !strings.ContainsAny(element, "/\\")
+}
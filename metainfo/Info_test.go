@@ -0,0 +1,43 @@
+package metainfo
+
+import (
+	"testing"
+
+	"github.com/vault-thirteen/auxie/tester"
+	"github.com/vault-thirteen/bencode"
+)
+
+func Test_Info_PieceHash(t *testing.T) {
+	var aTest = tester.New(t)
+
+	var pieces = make([]byte, PieceHashSize*2)
+	pieces[PieceHashSize] = 0xFF
+
+	var info = Info{Pieces: pieces}
+	aTest.MustBeEqual(info.PieceCount(), 2)
+	aTest.MustBeEqual(info.PieceHash(1)[0], byte(0xFF))
+	aTest.MustBeEqual(len(info.PieceHash(1)), PieceHashSize)
+}
+
+func Test_checkInfo(t *testing.T) {
+	var aTest = tester.New(t)
+
+	var info = bencode.Dict{
+		{Key: []byte("name"), Value: bencode.String("..")},
+	}
+	var problems = checkInfo(info)
+	aTest.MustBeEqual(problems, bencode.ValidationErrors{
+		{Path: "info.name", Message: `bad path element: ".."`},
+	})
+}
+
+func Test_isSafePathElement(t *testing.T) {
+	var aTest = tester.New(t)
+
+	aTest.MustBeEqual(isSafePathElement("a.txt"), true)
+	aTest.MustBeEqual(isSafePathElement(""), false)
+	aTest.MustBeEqual(isSafePathElement("."), false)
+	aTest.MustBeEqual(isSafePathElement(".."), false)
+	aTest.MustBeEqual(isSafePathElement("a/b"), false)
+	aTest.MustBeEqual(isSafePathElement(`a\b`), false)
+}
@@ -0,0 +1,198 @@
+// Package metainfo provides a typed model of BitTorrent metainfo files, also
+// known as torrent files, as described in BEP 3.
+package metainfo
+
+import (
+	"time"
+
+	"github.com/vault-thirteen/bencode"
+)
+
+// MetaInfo is the contents of a metainfo file. Optional fields which are
+// absent in the file are left empty, e.g. the creation date is zero.
+// InfoHash is calculated from the exact bytes of the info dictionary when
+// the source data is known.
+type MetaInfo struct {
+	Announce     string
+	AnnounceList [][]string
+	Comment      string
+	CreatedBy    string
+	CreationDate time.Time
+	Encoding     string
+	Info         Info
+	InfoHash     bencode.InfoHash
+}
+
+// Parse parses a 'bencoded' metainfo file. The data must contain exactly one
+// value. Validation problems are reported as bencode.ValidationErrors.
+func Parse(data []byte) (mi *MetaInfo, err error) {
+	var rawObject any
+	rawObject, err = bencode.DecodeBytes[any](data)
+	if err != nil {
+		return nil, err
+	}
+
+	mi, err = FromRawObject(rawObject)
+	if err != nil {
+		return nil, err
+	}
+
+	mi.InfoHash, err = bencode.InfoHashV1(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return mi, nil
+}
+
+// ParseFile reads and parses a metainfo file. Compressed files are
+// supported. Validation problems are reported as bencode.ValidationErrors.
+func ParseFile(filePath string) (mi *MetaInfo, err error) {
+	var object *bencode.DecodedObject
+	object, err = bencode.NewFile(filePath).Parse(false)
+	if err != nil {
+		return nil, err
+	}
+
+	mi, err = FromRawObject(object.RawObject)
+	if err != nil {
+		return nil, err
+	}
+
+	mi.InfoHash, err = object.InfoHashV1()
+	if err != nil {
+		return nil, err
+	}
+
+	return mi, nil
+}
+
+// FromRawObject validates a decoded metainfo file and converts it into the
+// typed model. The object may be given in any form accepted by
+// bencode.ToValue. All validation problems are reported at once as
+// bencode.ValidationErrors. The info hash is not calculated, as the source
+// data is unknown.
+func FromRawObject(rawObject any) (mi *MetaInfo, err error) {
+	err = bencode.Validate(rawObject, Schema())
+	if err != nil {
+		return nil, err
+	}
+
+	var value bencode.Value
+	value, err = bencode.ToValue(rawObject)
+	if err != nil {
+		return nil, err
+	}
+
+	// The schema guarantees the kinds of the values.
+	var root, _ = value.AsDict()
+	var info, _ = lookup(root, KeyInfo).AsDict()
+
+	var problems = checkInfo(info)
+	if len(problems) > 0 {
+		return nil, problems
+	}
+
+	mi = &MetaInfo{
+		Announce:     getString(root, KeyAnnounce),
+		AnnounceList: getAnnounceList(root),
+		Comment:      getString(root, KeyComment),
+		CreatedBy:    getString(root, KeyCreatedBy),
+		Encoding:     getString(root, KeyEncoding),
+		Info:         newInfo(info),
+	}
+
+	if lookup(root, KeyCreationDate) != nil {
+		mi.CreationDate = time.Unix(getInt(root, KeyCreationDate), 0).UTC()
+	}
+
+	return mi, nil
+}
+
+// Trackers returns the announce URLs of all the trackers. The announce list
+// is preferred when it is set, as described in BEP 12.
+func (mi *MetaInfo) Trackers() (urls []string) {
+	for _, tier := range mi.AnnounceList {
+		urls = append(urls, tier...)
+	}
+
+	if (len(urls) == 0) && (len(mi.Announce) > 0) {
+		urls = append(urls, mi.Announce)
+	}
+
+	return urls
+}
+
+// getAnnounceList converts the list of tiers of announce URLs.
+func getAnnounceList(root bencode.Dict) (announceList [][]string) {
+	var tiers = asList(lookup(root, KeyAnnounceList))
+	for _, tier := range tiers {
+		var urls, _ = tier.AsList()
+		announceList = append(announceList, toStrings(urls))
+	}
+
+	return announceList
+}
+
+// lookup finds a value of a dictionary by its key. Nil is returned when the
+// key is not found.
+func lookup(dict bencode.Dict, key string) (value bencode.Value) {
+	for _, entry := range dict {
+		if string(entry.Key) == key {
+			return entry.Value
+		}
+	}
+
+	return nil
+}
+
+// getString returns a byte string of a dictionary as a string.
+func getString(dict bencode.Dict, key string) string {
+	return string(getBytes(dict, key))
+}
+
+// getBytes returns a byte string of a dictionary.
+func getBytes(dict bencode.Dict, key string) (ba []byte) {
+	var value = lookup(dict, key)
+	if value == nil {
+		return nil
+	}
+
+	ba, _ = value.AsBytes()
+
+	return ba
+}
+
+// getInt returns an integer of a dictionary.
+func getInt(dict bencode.Dict, key string) (n int64) {
+	var value = lookup(dict, key)
+	if value == nil {
+		return 0
+	}
+
+	n, _ = value.AsInt()
+
+	return n
+}
+
+// asList returns the value as a list. An absent value is an empty list.
+func asList(value bencode.Value) (list bencode.List) {
+	if value == nil {
+		return nil
+	}
+
+	list, _ = value.AsList()
+
+	return list
+}
+
+// toStrings converts a list of byte strings into strings.
+func toStrings(list bencode.List) (strs []string) {
+	strs = make([]string, 0, len(list))
+	for _, item := range list {
+		var ba, _ = item.AsBytes()
+		strs = append(strs, string(ba))
+	}
+
+	return strs
+}
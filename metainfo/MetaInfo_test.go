@@ -0,0 +1,160 @@
+package metainfo
+
+import (
+	"crypto/sha1"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/vault-thirteen/auxie/tester"
+	"github.com/vault-thirteen/bencode"
+)
+
+// Test Settings.
+var TestTorrentFilePath = filepath.Join("..", "example", "data", "5942384.torrent")
+
+func Test_ParseFile(t *testing.T) {
+	var aTest = tester.New(t)
+
+	// Test #1. Positive.
+	{
+		mi, err := ParseFile(TestTorrentFilePath)
+		aTest.MustBeNoError(err)
+		aTest.MustBeEqual(mi.Announce, "http://bt.t-ru.org/ann")
+		aTest.MustBeEqual(mi.AnnounceList, [][]string{
+			{"http://bt.t-ru.org/ann"},
+			{"http://retracker.local/announce"},
+		})
+		aTest.MustBeEqual(mi.Comment, "https://rutracker.org/forum/viewtopic.php?t=5942384")
+		aTest.MustBeEqual(mi.CreatedBy, "")
+		aTest.MustBeEqual(mi.CreationDate.Unix(), int64(1600228636))
+		aTest.MustBeEqual(mi.Info.Name, "Formula 1 - S2020E50 - Toscana (Race) (1080p HDTV x265 SDR AAC 2.0 - Weasley HONE).mkv")
+		aTest.MustBeEqual(mi.Info.Length, int64(12556422657))
+		aTest.MustBeEqual(mi.Info.PieceLength, int64(4194304))
+		aTest.MustBeEqual(mi.Info.PieceCount(), 2994)
+		aTest.MustBeEqual(mi.Info.IsMultiFile(), false)
+		aTest.MustBeEqual(mi.Info.Private, false)
+		aTest.MustBeEqual(mi.Trackers(), []string{"http://bt.t-ru.org/ann", "http://retracker.local/announce"})
+		aTest.MustBeEqual(mi.InfoHash.Hex(), "9ddf6a9b17b624991b39f8afd2edc64f673350e3")
+	}
+
+	// Test #2. Negative.
+	{
+		_, err := ParseFile(filepath.Join("..", "example", "data", "none.torrent"))
+		aTest.MustBeAnError(err)
+	}
+}
+
+func Test_Parse(t *testing.T) {
+	var aTest = tester.New(t)
+
+	// Test #1. Positive: multi-file torrent.
+	{
+		data, err := bencode.TextToBencode([]byte(`{
+			"announce": "http://tracker/announce"
+			"created by": "test"
+			"creation date": 1600000000
+			"encoding": "UTF-8"
+			"info": {
+				"files": [
+					{ "length": 3, "path": ["a", "b.txt"] }
+					{ "length": 0, "path": ["c.txt"] }
+				]
+				"name": "dir"
+				"piece length": 16384
+				"pieces": #0102030405060708091011121314151617181920
+				"private": 1
+			}
+		}`))
+		aTest.MustBeNoError(err)
+
+		mi, err := Parse(data)
+		aTest.MustBeNoError(err)
+		aTest.MustBeEqual(mi.CreatedBy, "test")
+		aTest.MustBeEqual(mi.Encoding, "UTF-8")
+		aTest.MustBeEqual(mi.AnnounceList, [][]string(nil))
+		aTest.MustBeEqual(mi.Trackers(), []string{"http://tracker/announce"})
+		aTest.MustBeEqual(mi.Info.Files, []File{
+			{Length: 3, Path: []string{"a", "b.txt"}},
+			{Length: 0, Path: []string{"c.txt"}},
+		})
+		aTest.MustBeEqual(mi.Info.IsMultiFile(), true)
+		aTest.MustBeEqual(mi.Info.TotalLength(), int64(3))
+		aTest.MustBeEqual(mi.Info.Private, true)
+
+		info, err := bencode.QueryOne(mustDecode(t, data), "info")
+		aTest.MustBeNoError(err)
+		infoData, err := bencode.NewEncoder().EncodeAnInterface(info)
+		aTest.MustBeNoError(err)
+		aTest.MustBeEqual(mi.InfoHash, bencode.InfoHash(sha1.Sum(infoData)))
+	}
+
+	// Test #2. Negative: schema problems.
+	{
+		data, err := bencode.TextToBencode([]byte(`{
+			"announce": 1
+			"info": { "name": "x", "piece length": 0, "pieces": "abc" }
+		}`))
+		aTest.MustBeNoError(err)
+
+		_, err = Parse(data)
+		aTest.MustBeAnError(err)
+
+		var problems bencode.ValidationErrors
+		aTest.MustBeEqual(errors.As(err, &problems), true)
+		aTest.MustBeEqual(len(problems), 4)
+	}
+
+	// Test #3. Negative: problems not described by the schema.
+	{
+		data, err := bencode.TextToBencode([]byte(`{
+			"info": {
+				"files": [ { "length": 1, "path": ["..", "passwd"] } ]
+				"length": 1
+				"name": "x"
+				"piece length": 1
+				"pieces": ""
+			}
+		}`))
+		aTest.MustBeNoError(err)
+
+		_, err = Parse(data)
+		aTest.MustBeAnError(err)
+		aTest.MustBeEqual(err.Error(), "info: both 'length' and 'files' are set; info.files[0].path[0]: bad path element: \"..\"")
+	}
+
+	// Test #4. Negative: syntax error.
+	{
+		_, err := Parse([]byte("d4:info"))
+		aTest.MustBeAnError(err)
+	}
+}
+
+func Test_FromRawObject(t *testing.T) {
+	var aTest = tester.New(t)
+
+	var rawObject = bencode.Dictionary{
+		{Key: []byte("info"), Value: bencode.Dictionary{
+			{Key: []byte("length"), Value: int64(5)},
+			{Key: []byte("name"), Value: []byte("a.txt")},
+			{Key: []byte("piece length"), Value: int64(1)},
+			{Key: []byte("pieces"), Value: []byte{}},
+		}},
+	}
+
+	mi, err := FromRawObject(rawObject)
+	aTest.MustBeNoError(err)
+	aTest.MustBeEqual(mi.Info.Name, "a.txt")
+	aTest.MustBeEqual(mi.Info.TotalLength(), int64(5))
+	aTest.MustBeEqual(mi.CreationDate.IsZero(), true)
+	aTest.MustBeEqual(mi.InfoHash, bencode.InfoHash{})
+}
+
+func mustDecode(t *testing.T, data []byte) (rawObject any) {
+	var aTest = tester.New(t)
+
+	rawObject, err := bencode.DecodeBytes[any](data)
+	aTest.MustBeNoError(err)
+
+	return rawObject
+}
@@ -0,0 +1,7 @@
+package metainfo
+
+// Error messages and formats.
+const (
+	ErrLengthAndFiles = "both 'length' and 'files' are set"
+	ErrFPathElement   = "bad path element: %q"
+)
@@ -0,0 +1,82 @@
+package metainfo
+
+import (
+	"math"
+
+	"github.com/vault-thirteen/bencode"
+)
+
+// Keys of a metainfo dictionary.
+const (
+	KeyAnnounce     = "announce"
+	KeyAnnounceList = "announce-list"
+	KeyComment      = "comment"
+	KeyCreatedBy    = "created by"
+	KeyCreationDate = "creation date"
+	KeyEncoding     = "encoding"
+	KeyInfo         = "info"
+)
+
+// Keys of an info dictionary.
+const (
+	KeyName        = "name"
+	KeyPieceLength = "piece length"
+	KeyPieces      = "pieces"
+	KeyLength      = "length"
+	KeyFiles       = "files"
+	KeyPrivate     = "private"
+	KeyPath        = "path"
+)
+
+// Schema returns the schema of a metainfo file as described in BEP 3. Keys
+// which are not described by the specification are allowed, keys are not
+// required to be sorted.
+func Schema() *bencode.Schema {
+	var str = &bencode.Schema{Kind: bencode.KindString}
+	var positive = &bencode.Schema{Kind: bencode.KindInt, IntRange: &bencode.Range{Min: 1, Max: math.MaxInt64}}
+	var nonNegative = &bencode.Schema{Kind: bencode.KindInt, IntRange: &bencode.Range{Min: 0, Max: math.MaxInt64}}
+	var nonEmpty = &bencode.Range{Min: 1, Max: math.MaxInt64}
+
+	var fileSchema = &bencode.Schema{
+		Kind: bencode.KindDict,
+		Fields: []bencode.Field{
+			{Key: KeyLength, Required: true, Schema: nonNegative},
+			{Key: KeyPath, Required: true, Schema: &bencode.Schema{Kind: bencode.KindList, Items: str, Count: nonEmpty}},
+		},
+		AllowUnknownKeys: true,
+	}
+
+	var infoSchema = &bencode.Schema{
+		Kind: bencode.KindDict,
+		OneOf: []*bencode.Schema{
+			{Fields: []bencode.Field{{Key: KeyLength, Required: true}}, AllowUnknownKeys: true},
+			{Fields: []bencode.Field{{Key: KeyFiles, Required: true}}, AllowUnknownKeys: true},
+		},
+		Fields: []bencode.Field{
+			{Key: KeyName, Required: true, Schema: str},
+			{Key: KeyPieceLength, Required: true, Schema: positive},
+			{Key: KeyPieces, Required: true, Schema: &bencode.Schema{Kind: bencode.KindString, LengthMultipleOf: PieceHashSize}},
+			{Key: KeyLength, Schema: nonNegative},
+			{Key: KeyFiles, Schema: &bencode.Schema{Kind: bencode.KindList, Items: fileSchema, Count: nonEmpty}},
+			{Key: KeyPrivate, Schema: &bencode.Schema{Kind: bencode.KindInt, IntRange: &bencode.Range{Min: 0, Max: 1}}},
+		},
+		AllowUnknownKeys: true,
+	}
+
+	return &bencode.Schema{
+		Kind: bencode.KindDict,
+		Fields: []bencode.Field{
+			{Key: KeyAnnounce, Schema: str},
+			{Key: KeyAnnounceList, Schema: &bencode.Schema{
+				Kind:  bencode.KindList,
+				Items: &bencode.Schema{Kind: bencode.KindList, Items: str, Count: nonEmpty},
+			}},
+			{Key: KeyComment, Schema: str},
+			{Key: KeyCreatedBy, Schema: str},
+			{Key: KeyCreationDate, Schema: &bencode.Schema{Kind: bencode.KindInt}},
+			{Key: KeyEncoding, Schema: str},
+			{Key: KeyInfo, Required: true, Schema: infoSchema},
+		},
+		AllowUnknownKeys: true,
+	}
+}
@@ -0,0 +1,217 @@
+package bencode
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Key paths.
+//
+// A path addresses a node of a decoded tree. Dictionary keys are joined with
+// dots, list indices are written in brackets, e.g. 'info.files[0].length'.
+// Keys which contain special symbols or are not printable are written as
+// quoted strings in brackets, e.g. 'files["\x12\x34"]'. The root node has an
+// empty path. A query path may also contain wildcards, e.g.
+// 'info.files[*].length'.
+
+// Special symbols of paths.
+const (
+	PathSeparator    = '.'
+	PathIndexStart   = '['
+	PathIndexEnd     = ']'
+	PathQuote        = '"'
+	PathWildcard     = '*'
+	PathEscapeSymbol = '\\'
+)
+
+// ByteStringDisplayMaxLength is the maximal number of bytes of a binary byte
+// string shown in a human-readable form.
+const ByteStringDisplayMaxLength = 32
+
+// appendPathKey appends a dictionary key to the path.
+func appendPathKey(path string, key []byte) string {
+	if !isSimplePathKey(key) {
+		return path + string(PathIndexStart) + strconv.Quote(string(key)) + string(PathIndexEnd)
+	}
+
+	if len(path) == 0 {
+		return string(key)
+	}
+
+	return path + string(PathSeparator) + string(key)
+}
+
+// appendPathIndex appends a list index to the path.
+func appendPathIndex(path string, index int) string {
+	return path + string(PathIndexStart) + strconv.Itoa(index) + string(PathIndexEnd)
+}
+
+// isSimplePathKey checks whether the key can be written in a path without
+// quotes.
+func isSimplePathKey(key []byte) bool {
+	if len(key) == 0 {
+		return false
+	}
+
+	for _, b := range key {
+		if (b < ' ') || (b > '~') {
+			return false
+		}
+
+		switch b {
+		case PathSeparator, PathIndexStart, PathIndexEnd, PathQuote, PathWildcard, PathEscapeSymbol:
+			return false
+		}
+	}
+
+	return true
+}
+
+// isPrintableText checks whether the byte string is a printable UTF-8 text.
+func isPrintableText(ba []byte) bool {
+	if !utf8.Valid(ba) {
+		return false
+	}
+
+	for _, r := range string(ba) {
+		if !unicode.IsPrint(r) && !unicode.IsSpace(r) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// formatByteString returns a human-readable form of a byte string. A
+// printable text is quoted, binary data is shown in hexadecimal form, long
+// binary data is truncated and followed by its length.
+func formatByteString(ba []byte) string {
+	if isPrintableText(ba) {
+		return strconv.Quote(string(ba))
+	}
+
+	if len(ba) <= ByteStringDisplayMaxLength {
+		return "0x" + hex.EncodeToString(ba)
+	}
+
+	return fmt.Sprintf("0x%s… (%d bytes)", hex.EncodeToString(ba[:ByteStringDisplayMaxLength]), len(ba))
+}
+
+// Types of path segments.
+const (
+	pathSegmentKey = iota + 1
+	pathSegmentIndex
+	pathSegmentWildcard
+)
+
+// pathSegment is a parsed segment of a path.
+type pathSegment struct {
+	kind  int
+	key   []byte
+	index int
+}
+
+// parsePath parses a path. Apart from the keys and indices, a path may
+// contain wildcards: '[*]' or '.*' select all elements of a list or all values
+// of a dictionary.
+func parsePath(path string) (segments []pathSegment, err error) {
+	var pos = 0
+	for pos < len(path) {
+		var segment pathSegment
+
+		switch {
+		case path[pos] == PathIndexStart:
+			segment, pos, err = parsePathBrackets(path, pos)
+
+		case (path[pos] == PathSeparator) && (pos > 0):
+			segment, pos, err = parsePathKey(path, pos+1)
+
+		case pos == 0:
+			segment, pos, err = parsePathKey(path, pos)
+
+		default:
+			err = fmt.Errorf(ErrFPathSyntax, pos, path)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		segments = append(segments, segment)
+	}
+
+	return segments, nil
+}
+
+// parsePathKey parses a key written without quotes or a wildcard.
+func parsePathKey(path string, start int) (segment pathSegment, pos int, err error) {
+	pos = start
+	for (pos < len(path)) && (path[pos] != PathSeparator) && (path[pos] != PathIndexStart) {
+		pos++
+	}
+
+	var key = path[start:pos]
+	if key == string(PathWildcard) {
+		return pathSegment{kind: pathSegmentWildcard}, pos, nil
+	}
+
+	if !isSimplePathKey([]byte(key)) {
+		return pathSegment{}, pos, fmt.Errorf(ErrFPathSyntax, start, path)
+	}
+
+	return pathSegment{kind: pathSegmentKey, key: []byte(key)}, pos, nil
+}
+
+// parsePathBrackets parses an index, a quoted key or a wildcard written in
+// brackets.
+func parsePathBrackets(path string, start int) (segment pathSegment, pos int, err error) {
+	pos = start + 1
+
+	// Quoted key.
+	if (pos < len(path)) && (path[pos] == PathQuote) {
+		var quoted string
+		quoted, err = strconv.QuotedPrefix(path[pos:])
+		if err != nil {
+			return pathSegment{}, pos, fmt.Errorf(ErrFPathSyntax, pos, path)
+		}
+
+		pos += len(quoted)
+		if (pos >= len(path)) || (path[pos] != PathIndexEnd) {
+			return pathSegment{}, pos, fmt.Errorf(ErrFPathSyntax, pos, path)
+		}
+
+		var key string
+		key, err = strconv.Unquote(quoted)
+		if err != nil {
+			return pathSegment{}, pos, fmt.Errorf(ErrFPathSyntax, start+1, path)
+		}
+
+		return pathSegment{kind: pathSegmentKey, key: []byte(key)}, pos + 1, nil
+	}
+
+	var end = strings.IndexByte(path[pos:], PathIndexEnd)
+	if end < 0 {
+		return pathSegment{}, pos, fmt.Errorf(ErrFPathSyntax, pos, path)
+	}
+
+	var contents = path[pos : pos+end]
+	pos += end + 1
+
+	// Wildcard.
+	if contents == string(PathWildcard) {
+		return pathSegment{kind: pathSegmentWildcard}, pos, nil
+	}
+
+	// Index.
+	var index int
+	index, err = strconv.Atoi(contents)
+	if (err != nil) || (index < 0) || (len(contents) == 0) || (contents[0] == '+') {
+		return pathSegment{}, pos, fmt.Errorf(ErrFPathSyntax, start+1, path)
+	}
+
+	return pathSegment{kind: pathSegmentIndex, index: index}, pos, nil
+}
@@ -0,0 +1,45 @@
+package bencode
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/vault-thirteen/auxie/tester"
+)
+
+func Test_appendPathKey(t *testing.T) {
+	var aTest = tester.New(t)
+
+	aTest.MustBeEqual(appendPathKey("", []byte("info")), "info")
+	aTest.MustBeEqual(appendPathKey("info", []byte("piece length")), "info.piece length")
+	aTest.MustBeEqual(appendPathKey("files", []byte{0x12, 0x34}), `files["\x124"]`)
+	aTest.MustBeEqual(appendPathKey("", []byte("a.b")), `["a.b"]`)
+	aTest.MustBeEqual(appendPathKey("x", []byte("")), `x[""]`)
+}
+
+func Test_appendPathIndex(t *testing.T) {
+	var aTest = tester.New(t)
+
+	aTest.MustBeEqual(appendPathIndex("", 0), "[0]")
+	aTest.MustBeEqual(appendPathIndex("announce-list[0]", 1), "announce-list[0][1]")
+}
+
+func Test_isPrintableText(t *testing.T) {
+	var aTest = tester.New(t)
+
+	aTest.MustBeEqual(isPrintableText([]byte("Hello, World!\n")), true)
+	aTest.MustBeEqual(isPrintableText([]byte("Привет")), true)
+	aTest.MustBeEqual(isPrintableText([]byte{0x00, 'a'}), false)
+	aTest.MustBeEqual(isPrintableText([]byte{0xFF}), false)
+}
+
+func Test_formatByteString(t *testing.T) {
+	var aTest = tester.New(t)
+
+	aTest.MustBeEqual(formatByteString([]byte("text")), `"text"`)
+	aTest.MustBeEqual(formatByteString([]byte{0x00, 0xAB}), "0x00ab")
+	aTest.MustBeEqual(
+		formatByteString(make([]byte, 40)),
+		"0x"+strings.Repeat("00", ByteStringDisplayMaxLength)+"… (40 bytes)",
+	)
+}
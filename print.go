@@ -0,0 +1,174 @@
+package bencode
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// PrintIndent is the indentation used by the pretty printer.
+const PrintIndent = "  "
+
+// printer is a pretty printer of values.
+type printer struct {
+	writer io.Writer
+	err    error
+}
+
+// Format returns a human-readable indented representation of a value. The
+// value may be given in the form returned by the decoder or as a strongly
+// typed value. Printable byte strings are quoted, binary byte strings, such
+// as pieces, peers or node IDs, are shown in hexadecimal form; long ones are
+// truncated and followed by their length.
+func Format(v any) string {
+	var sb strings.Builder
+	_ = Fprint(&sb, v)
+
+	return sb.String()
+}
+
+// Fprint writes a human-readable indented representation of a value into the
+// writer. See Format for details.
+func Fprint(w io.Writer, v any) (err error) {
+	var p = &printer{
+		writer: w,
+	}
+
+	p.printValue(v, 0)
+
+	return p.err
+}
+
+// print writes a text.
+func (p *printer) print(text string) {
+	if p.err != nil {
+		return
+	}
+
+	_, p.err = io.WriteString(p.writer, text)
+}
+
+// printIndented writes a text on a new line with indentation.
+func (p *printer) printIndented(text string, depth int) {
+	p.print("\n" + strings.Repeat(PrintIndent, depth) + text)
+}
+
+// printValue writes a value.
+func (p *printer) printValue(v any, depth int) {
+	switch x := v.(type) {
+	case []DictionaryItem:
+		p.printDictionary(len(x), depth, func(i int) ([]byte, any) {
+			return x[i].Key, x[i].Value
+		})
+
+	case Dictionary:
+		p.printValue([]DictionaryItem(x), depth)
+
+	case Dict:
+		p.printDictionary(len(x), depth, func(i int) ([]byte, any) {
+			return x[i].Key, x[i].Value
+		})
+
+	case []any:
+		p.printList(len(x), depth, func(i int) any {
+			return x[i]
+		})
+
+	case List:
+		p.printList(len(x), depth, func(i int) any {
+			return x[i]
+		})
+
+	default:
+		var value, err = ToValue(v)
+		if err != nil {
+			p.print(fmt.Sprintf("<unsupported %T>", v))
+			return
+		}
+
+		switch scalar := value.(type) {
+		case Int:
+			p.print(strconv.FormatInt(int64(scalar), 10))
+		case String:
+			p.print(formatByteString(scalar))
+		default:
+			p.printValue(value, depth)
+		}
+	}
+}
+
+// printDictionary writes a dictionary.
+func (p *printer) printDictionary(size int, depth int, item func(i int) ([]byte, any)) {
+	if size == 0 {
+		p.print("{}")
+		return
+	}
+
+	p.print("{")
+	for i := 0; i < size; i++ {
+		var key, value = item(i)
+		p.printIndented(formatByteString(key)+": ", depth+1)
+		p.printValue(value, depth+1)
+	}
+	p.printIndented("}", depth)
+}
+
+// printList writes a list.
+func (p *printer) printList(size int, depth int, item func(i int) any) {
+	if size == 0 {
+		p.print("[]")
+		return
+	}
+
+	p.print("[")
+	for i := 0; i < size; i++ {
+		p.printIndented("", depth+1)
+		p.printValue(item(i), depth+1)
+	}
+	p.printIndented("]", depth)
+}
+
+// formatContainer implements the fmt.Formatter interface for containers. The
+// 'v' and 's' verbs produce the pretty printer's output.
+func formatContainer(f fmt.State, verb rune, v any) {
+	switch verb {
+	case 'v', 's':
+		_ = Fprint(f, v)
+	default:
+		_, _ = fmt.Fprintf(f, "%%!%c(%T)", verb, v)
+	}
+}
+
+// Format implements the fmt.Formatter interface. Verbs are applied to the
+// integer as to the int64 type.
+func (i Int) Format(f fmt.State, verb rune) {
+	_, _ = fmt.Fprintf(f, fmt.FormatString(f, verb), int64(i))
+}
+
+// Format implements the fmt.Formatter interface. The 'v' verb produces the
+// pretty printer's output, other verbs are applied to the byte string as to a
+// byte slice.
+func (s String) Format(f fmt.State, verb rune) {
+	if verb == 'v' {
+		_, _ = io.WriteString(f, formatByteString(s))
+		return
+	}
+
+	_, _ = fmt.Fprintf(f, fmt.FormatString(f, verb), []byte(s))
+}
+
+// Format implements the fmt.Formatter interface, see formatContainer.
+func (l List) Format(f fmt.State, verb rune) {
+	formatContainer(f, verb, l)
+}
+
+// Format implements the fmt.Formatter interface, see formatContainer.
+func (d Dict) Format(f fmt.State, verb rune) {
+	formatContainer(f, verb, d)
+}
+
+// Format implements the fmt.Formatter interface, see formatContainer.
+func (d Dictionary) Format(f fmt.State, verb rune) {
+	formatContainer(f, verb, d)
+}
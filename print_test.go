@@ -0,0 +1,68 @@
+package bencode
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/vault-thirteen/auxie/tester"
+)
+
+// failingWriter is a writer which always fails.
+type failingWriter struct{}
+
+func (w failingWriter) Write(p []byte) (n int, err error) {
+	return 0, errors.New("write error")
+}
+
+func Test_Format(t *testing.T) {
+	var aTest = tester.New(t)
+
+	// Test #1. Decoded value.
+	{
+		var tree, err = DecodeBytes[any]([]byte("d8:announce3:url4:infod6:lengthi5e6:pieces3:\x00\x01\x02e4:listli1ele0:deee"))
+		aTest.MustBeNoError(err)
+		aTest.MustBeEqual(Format(tree), `{
+  "announce": "url"
+  "info": {
+    "length": 5
+    "pieces": 0x000102
+  }
+  "list": [
+    1
+    []
+    ""
+    {}
+  ]
+}`)
+	}
+
+	// Test #2. Scalars and other types.
+	{
+		aTest.MustBeEqual(Format(7), "7")
+		aTest.MustBeEqual(Format("abc"), `"abc"`)
+		aTest.MustBeEqual(Format(List{Int(1), String{0xFF}}), "[\n  1\n  0xff\n]")
+		aTest.MustBeEqual(Format(Dictionary{{Key: []byte("k"), Value: Dict{}}}), "{\n  \"k\": {}\n}")
+		aTest.MustBeEqual(Format(time.Time{}), "<unsupported time.Time>")
+	}
+}
+
+func Test_Fprint(t *testing.T) {
+	var aTest = tester.New(t)
+
+	var err = Fprint(failingWriter{}, []any{int64(1), int64(2)})
+	aTest.MustBeAnError(err)
+}
+
+func Test_Value_Format(t *testing.T) {
+	var aTest = tester.New(t)
+
+	aTest.MustBeEqual(fmt.Sprintf("%v|%05d|%x", Int(42), Int(42), Int(42)), "42|00042|2a")
+	aTest.MustBeEqual(fmt.Sprintf("%v|%s|%x", String("ab"), String("ab"), String("ab")), `"ab"|ab|6162`)
+	aTest.MustBeEqual(fmt.Sprintf("%v", String{0x01, 0x02}), "0x0102")
+	aTest.MustBeEqual(fmt.Sprint(List{Int(1)}), "[\n  1\n]")
+	aTest.MustBeEqual(fmt.Sprintf("%s", Dict{{Key: []byte("a"), Value: Int(1)}}), "{\n  \"a\": 1\n}")
+	aTest.MustBeEqual(fmt.Sprintf("%v", Dictionary{}), "{}")
+	aTest.MustBeEqual(fmt.Sprintf("%d", List{}), "%!d(bencode.List)")
+}
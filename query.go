@@ -0,0 +1,225 @@
+package bencode
+
+import (
+	"fmt"
+	"slices"
+)
+
+// Query and modification of decoded trees by paths. The functions work with
+// the values in the form returned by the decoder: []DictionaryItem for
+// dictionaries and []any for lists.
+
+// Operations of path modification.
+const (
+	pathOperationSet = iota + 1
+	pathOperationDelete
+)
+
+// Query returns all the values addressed by the path, e.g.
+// 'info.files[*].length' or 'announce-list[0][0]'. Nodes which do not exist
+// are not an error, they are simply not included into the result.
+func Query(v any, path string) (results []any, err error) {
+	var segments []pathSegment
+	segments, err = parsePath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return queryNodes(results, v, segments), nil
+}
+
+// QueryOne returns the first value addressed by the path. It is an error when
+// nothing is found.
+func QueryOne(v any, path string) (result any, err error) {
+	var results []any
+	results, err = Query(v, path)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(results) == 0 {
+		return nil, fmt.Errorf(ErrFPathIsNotFound, path)
+	}
+
+	return results[0], nil
+}
+
+// Set sets the value of all the nodes addressed by the path. A missing key of
+// a dictionary is inserted at the position which keeps the canonical order of
+// keys; an index equal to the length of a list appends a new element. All the
+// other nodes of the path must exist. The tree is changed in place, but the
+// returned root must be used, while containers may be reallocated.
+func Set(root any, path string, value any) (result any, err error) {
+	return editPath(root, path, pathOperationSet, value)
+}
+
+// Delete deletes all the nodes addressed by the path. The tree is changed in
+// place, but the returned root must be used, while containers may be
+// reallocated.
+func Delete(root any, path string) (result any, err error) {
+	return editPath(root, path, pathOperationDelete, nil)
+}
+
+// queryNodes appends the nodes addressed by the path segments to the list.
+func queryNodes(results []any, node any, segments []pathSegment) []any {
+	if len(segments) == 0 {
+		return append(results, node)
+	}
+
+	var segment = segments[0]
+	switch x := node.(type) {
+	case []DictionaryItem:
+		for _, item := range x {
+			if (segment.kind == pathSegmentWildcard) ||
+				((segment.kind == pathSegmentKey) && (string(item.Key) == string(segment.key))) {
+				results = queryNodes(results, item.Value, segments[1:])
+			}
+		}
+
+	case []any:
+		switch segment.kind {
+		case pathSegmentWildcard:
+			for _, item := range x {
+				results = queryNodes(results, item, segments[1:])
+			}
+
+		case pathSegmentIndex:
+			if segment.index < len(x) {
+				results = queryNodes(results, x[segment.index], segments[1:])
+			}
+		}
+	}
+
+	return results
+}
+
+// editPath applies the operation to the nodes addressed by the path.
+func editPath(root any, path string, operation int, value any) (result any, err error) {
+	var segments []pathSegment
+	segments, err = parsePath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	// The root itself.
+	if len(segments) == 0 {
+		if operation == pathOperationSet {
+			return value, nil
+		}
+
+		return nil, nil
+	}
+
+	var count int
+	result, count = editNode(root, segments, operation, value)
+	if count == 0 {
+		return root, fmt.Errorf(ErrFPathIsNotFound, path)
+	}
+
+	return result, nil
+}
+
+// editNode applies the operation to the children of the node addressed by the
+// path segments. It returns the changed node and the number of changes.
+func editNode(node any, segments []pathSegment, operation int, value any) (result any, count int) {
+	if len(segments) == 1 {
+		return editLastSegment(node, segments[0], operation, value)
+	}
+
+	var segment = segments[0]
+	var n int
+	switch x := node.(type) {
+	case []DictionaryItem:
+		for i := range x {
+			if (segment.kind == pathSegmentWildcard) ||
+				((segment.kind == pathSegmentKey) && (string(x[i].Key) == string(segment.key))) {
+				x[i].Value, n = editNode(x[i].Value, segments[1:], operation, value)
+				x[i].ValueStr = convertInterfaceToString(x[i].Value)
+				count += n
+			}
+		}
+
+	case []any:
+		switch segment.kind {
+		case pathSegmentWildcard:
+			for i := range x {
+				x[i], n = editNode(x[i], segments[1:], operation, value)
+				count += n
+			}
+
+		case pathSegmentIndex:
+			if segment.index < len(x) {
+				x[segment.index], count = editNode(x[segment.index], segments[1:], operation, value)
+			}
+		}
+	}
+
+	return node, count
+}
+
+// editLastSegment applies the operation to the children of the node addressed
+// by the last segment of a path.
+func editLastSegment(node any, segment pathSegment, operation int, value any) (result any, count int) {
+	switch x := node.(type) {
+	case []DictionaryItem:
+		var dictionary = Dictionary(x)
+
+		switch segment.kind {
+		case pathSegmentWildcard:
+			if operation == pathOperationDelete {
+				return x[:0:0], len(x)
+			}
+
+			for i := range dictionary {
+				dictionary[i].Value = value
+				dictionary[i].ValueStr = convertInterfaceToString(value)
+			}
+			return []DictionaryItem(dictionary), len(dictionary)
+
+		case pathSegmentKey:
+			if operation == pathOperationDelete {
+				var oldLen = len(dictionary)
+				dictionary.Delete(string(segment.key))
+				return []DictionaryItem(dictionary), oldLen - len(dictionary)
+			}
+
+			dictionary.Set(string(segment.key), value)
+			return []DictionaryItem(dictionary), 1
+		}
+
+	case []any:
+		switch segment.kind {
+		case pathSegmentWildcard:
+			if operation == pathOperationDelete {
+				return x[:0:0], len(x)
+			}
+
+			for i := range x {
+				x[i] = value
+			}
+			return x, len(x)
+
+		case pathSegmentIndex:
+			if segment.index > len(x) {
+				break
+			}
+
+			if operation == pathOperationDelete {
+				if segment.index == len(x) {
+					break
+				}
+
+				return slices.Delete(x, segment.index, segment.index+1), 1
+			}
+
+			if segment.index == len(x) {
+				return append(x, value), 1
+			}
+
+			x[segment.index] = value
+			return x, 1
+		}
+	}
+
+	return node, 0
+}
@@ -0,0 +1,191 @@
+package bencode
+
+import (
+	"testing"
+
+	"github.com/vault-thirteen/auxie/tester"
+)
+
+// newQueryTestTree creates a tree for the tests of queries.
+func newQueryTestTree(t *testing.T) any {
+	var aTest = tester.New(t)
+
+	var tree, err = DecodeBytes[any]([]byte(
+		"d8:announce3:one13:announce-listll3:oneel3:twoee" +
+			"4:infod5:filesld6:lengthi1e4:pathl1:aeed6:lengthi2e4:pathl1:beee4:name1:xee",
+	))
+	aTest.MustBeNoError(err)
+
+	return tree
+}
+
+func Test_parsePath(t *testing.T) {
+	var aTest = tester.New(t)
+
+	// Test #1. Positive.
+	{
+		segments, err := parsePath(`info.files[*].path[0]["a.b"].*`)
+		aTest.MustBeNoError(err)
+		aTest.MustBeEqual(segments, []pathSegment{
+			{kind: pathSegmentKey, key: []byte("info")},
+			{kind: pathSegmentKey, key: []byte("files")},
+			{kind: pathSegmentWildcard},
+			{kind: pathSegmentKey, key: []byte("path")},
+			{kind: pathSegmentIndex, index: 0},
+			{kind: pathSegmentKey, key: []byte("a.b")},
+			{kind: pathSegmentWildcard},
+		})
+
+		segments, err = parsePath("")
+		aTest.MustBeNoError(err)
+		aTest.MustBeEqual(len(segments), 0)
+	}
+
+	// Test #2. Negative.
+	{
+		for _, path := range []string{".a", "a..b", "a[", "a[x]", "a[-1]", "a[+1]", `a["b`, `a["b"`, "a[0]b", "a.b]"} {
+			_, err := parsePath(path)
+			aTest.MustBeAnError(err)
+		}
+	}
+}
+
+func Test_Query(t *testing.T) {
+	var aTest = tester.New(t)
+
+	var tree = newQueryTestTree(t)
+
+	// Test #1. Positive.
+	{
+		results, err := Query(tree, "info.files[*].length")
+		aTest.MustBeNoError(err)
+		aTest.MustBeEqual(results, []any{int64(1), int64(2)})
+
+		results, err = Query(tree, "announce-list[1][0]")
+		aTest.MustBeNoError(err)
+		aTest.MustBeEqual(results, []any{[]byte("two")})
+
+		results, err = Query(tree, "info.*")
+		aTest.MustBeNoError(err)
+		aTest.MustBeEqual(len(results), 2)
+
+		results, err = Query(tree, "info.files[5].length")
+		aTest.MustBeNoError(err)
+		aTest.MustBeEqual(len(results), 0)
+	}
+
+	// Test #2. Negative.
+	{
+		_, err := Query(tree, "info..files")
+		aTest.MustBeAnError(err)
+	}
+}
+
+func Test_QueryOne(t *testing.T) {
+	var aTest = tester.New(t)
+
+	var tree = newQueryTestTree(t)
+
+	result, err := QueryOne(tree, "info.name")
+	aTest.MustBeNoError(err)
+	aTest.MustBeEqual(result, []byte("x"))
+
+	_, err = QueryOne(tree, "info.length")
+	aTest.MustBeAnError(err)
+
+	_, err = QueryOne(tree, "info[")
+	aTest.MustBeAnError(err)
+}
+
+func Test_Set(t *testing.T) {
+	var aTest = tester.New(t)
+
+	var tree = newQueryTestTree(t)
+	var err error
+
+	// Test #1. Positive.
+	{
+		tree, err = Set(tree, "info.files[*].length", int64(7))
+		aTest.MustBeNoError(err)
+
+		tree, err = Set(tree, "comment", []byte("new"))
+		aTest.MustBeNoError(err)
+
+		tree, err = Set(tree, "announce-list[2]", []any{[]byte("three")})
+		aTest.MustBeNoError(err)
+
+		tree, err = Set(tree, "info.name", []byte("y"))
+		aTest.MustBeNoError(err)
+
+		encoded, err := NewEncoder().EncodeAnInterface(tree)
+		aTest.MustBeNoError(err)
+		aTest.MustBeEqual(string(encoded),
+			"d8:announce3:one13:announce-listll3:oneel3:twoel5:threeee7:comment3:new"+
+				"4:infod5:filesld6:lengthi7e4:pathl1:aeed6:lengthi7e4:pathl1:beee4:name1:yee",
+		)
+	}
+
+	// Test #2. Root.
+	{
+		result, err := Set(tree, "", int64(1))
+		aTest.MustBeNoError(err)
+		aTest.MustBeEqual(result, int64(1))
+	}
+
+	// Test #3. Negative.
+	{
+		_, err = Set(tree, "info.pieces.x", int64(1))
+		aTest.MustBeAnError(err)
+
+		_, err = Set(tree, "announce-list[9]", int64(1))
+		aTest.MustBeAnError(err)
+
+		_, err = Set(tree, "announce.x", int64(1))
+		aTest.MustBeAnError(err)
+
+		_, err = Set(tree, "a[", int64(1))
+		aTest.MustBeAnError(err)
+	}
+}
+
+func Test_Delete(t *testing.T) {
+	var aTest = tester.New(t)
+
+	var tree = newQueryTestTree(t)
+	var err error
+
+	// Test #1. Positive.
+	{
+		tree, err = Delete(tree, "info.files[*].path")
+		aTest.MustBeNoError(err)
+
+		tree, err = Delete(tree, "announce-list[0]")
+		aTest.MustBeNoError(err)
+
+		tree, err = Delete(tree, "announce")
+		aTest.MustBeNoError(err)
+
+		tree, err = Delete(tree, "announce-list[*]")
+		aTest.MustBeNoError(err)
+
+		encoded, err := NewEncoder().EncodeAnInterface(tree)
+		aTest.MustBeNoError(err)
+		aTest.MustBeEqual(string(encoded), "d13:announce-listle4:infod5:filesld6:lengthi1eed6:lengthi2eee4:name1:xee")
+
+		tree, err = Delete(tree, "info.*")
+		aTest.MustBeNoError(err)
+
+		encoded, err = NewEncoder().EncodeAnInterface(tree)
+		aTest.MustBeNoError(err)
+		aTest.MustBeEqual(string(encoded), "d13:announce-listle4:infodee")
+	}
+
+	// Test #2. Negative.
+	{
+		_, err = Delete(tree, "announce")
+		aTest.MustBeAnError(err)
+
+		_, err = Delete(tree, "announce-list[0]")
+		aTest.MustBeAnError(err)
+	}
+}
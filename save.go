@@ -0,0 +1,203 @@
+package bencode
+
+import (
+	"crypto/sha256"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	ae "github.com/vault-thirteen/auxie/errors"
+)
+
+// Settings of saving files.
+const (
+	BackupFileExtension    = ".bak"
+	TemporaryFileExtension = ".tmp"
+	NewFileMode            = 0644
+)
+
+// Save encodes the value and writes it into the file. The data is written
+// into a temporary file in the same folder, synchronized with the disk and
+// then renamed over the original file, so that the file is never left
+// partially written. If 'keepBackup' flag is enabled, the previous contents
+// of the file are kept in a file with the '.bak' extension.
+func (f *File) Save(value any, keepBackup bool) (err error) {
+	var data []byte
+	data, err = NewEncoder().EncodeAnInterface(value)
+	if err != nil {
+		return err
+	}
+
+	return saveData(f.path, data, keepBackup)
+}
+
+// WriteFile encodes the decoded data and writes it back into the file from
+// which it was decoded. See File.Save for details. The data is compressed
+// with the compression format of the file; 'bzip2' files can not be written.
+// On success, the encoded data becomes the new source data of the object and
+// the meta-data of the file is updated.
+func (do *DecodedObject) WriteFile(keepBackup bool) (err error) {
+	if len(do.FilePath) == 0 {
+		return errors.New(ErrFilePathIsNotSet)
+	}
+
+	var data []byte
+	data, err = NewEncoder().EncodeAnInterface(do.RawObject)
+	if err != nil {
+		return err
+	}
+
+	var fileData []byte
+	fileData, err = compressData(data, do.Compression)
+	if err != nil {
+		return err
+	}
+
+	err = saveData(do.FilePath, fileData, keepBackup)
+	if err != nil {
+		return err
+	}
+
+	do.SourceData = data
+	do.FileSize = int64(len(fileData))
+	do.SourceSHA256 = sha256.Sum256(data)
+
+	var fileInfo fs.FileInfo
+	fileInfo, err = os.Stat(do.FilePath)
+	if err != nil {
+		return err
+	}
+
+	do.FileModTime = fileInfo.ModTime()
+
+	return nil
+}
+
+// saveData writes the data into the file atomically, optionally keeping a
+// backup copy of the previous contents. A symbolic link is followed, so that
+// the file it points to is written instead of replacing the link.
+func saveData(filePath string, data []byte, keepBackup bool) (err error) {
+	filePath, err = resolveSymlinks(filePath)
+	if err != nil {
+		return err
+	}
+
+	if keepBackup {
+		var oldData []byte
+		oldData, err = os.ReadFile(filePath)
+		if err == nil {
+			err = writeFileAtomically(filePath+BackupFileExtension, oldData)
+		}
+		if (err != nil) && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+	}
+
+	return writeFileAtomically(filePath, data)
+}
+
+// resolveSymlinks returns the path with all the symbolic links resolved. A
+// path of a file which does not exist yet is returned as is, while a broken
+// link is an error.
+func resolveSymlinks(filePath string) (realPath string, err error) {
+	realPath, err = filepath.EvalSymlinks(filePath)
+	if err == nil {
+		return realPath, nil
+	}
+
+	if !errors.Is(err, fs.ErrNotExist) {
+		return "", err
+	}
+
+	_, lerr := os.Lstat(filePath)
+	if lerr == nil {
+		// The path exists, but the target of the link does not.
+		return "", err
+	}
+
+	return filePath, nil
+}
+
+// writeFileAtomically writes the data into a temporary file and renames it
+// over the target file. Permissions of an existing file are kept.
+func writeFileAtomically(filePath string, data []byte) (err error) {
+	var fileMode fs.FileMode = NewFileMode
+	var fileInfo fs.FileInfo
+	fileInfo, err = os.Stat(filePath)
+	if err == nil {
+		fileMode = fileInfo.Mode().Perm()
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	var folder = filepath.Dir(filePath)
+	var tmpFile *os.File
+	tmpFile, err = os.CreateTemp(folder, "."+filepath.Base(filePath)+".*"+TemporaryFileExtension)
+	if err != nil {
+		return err
+	}
+
+	var isClosed = false
+	defer func() {
+		// Clean up after a failure.
+		if err == nil {
+			return
+		}
+
+		if !isClosed {
+			derr := tmpFile.Close()
+			if derr != nil {
+				err = ae.Combine(err, derr)
+			}
+		}
+
+		derr := os.Remove(tmpFile.Name())
+		if derr != nil {
+			err = ae.Combine(err, derr)
+		}
+	}()
+
+	_, err = tmpFile.Write(data)
+	if err != nil {
+		return err
+	}
+
+	err = tmpFile.Chmod(fileMode)
+	if err != nil {
+		return err
+	}
+
+	err = tmpFile.Sync()
+	if err != nil {
+		return err
+	}
+
+	isClosed = true
+	err = tmpFile.Close()
+	if err != nil {
+		return err
+	}
+
+	err = os.Rename(tmpFile.Name(), filePath)
+	if err != nil {
+		return err
+	}
+
+	syncFolder(folder)
+
+	return nil
+}
+
+// syncFolder synchronizes the folder with the disk, so that a renamed file
+// survives a crash. Not all operating systems support this, so errors are
+// ignored.
+func syncFolder(folder string) {
+	var dir, err = os.Open(folder)
+	if err != nil {
+		return
+	}
+
+	_ = dir.Sync()
+	_ = dir.Close()
+}
@@ -0,0 +1,228 @@
+package bencode
+
+import (
+	"crypto/sha256"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/vault-thirteen/auxie/tester"
+)
+
+func Test_File_Save(t *testing.T) {
+	var aTest = tester.New(t)
+
+	// Test Initialization.
+	createTestFolder(t)
+	createTestFileB(t)
+	filePath := filepath.Join(TestFolder, TestFileBName)
+	var f = NewFile(filePath)
+
+	// Test Finalization.
+	defer func() {
+		deleteTestFolder(t)
+	}()
+
+	var data []byte
+	var err error
+
+	err = os.Chmod(filePath, 0600)
+	aTest.MustBeNoError(err)
+
+	// Test #1. Positive with a backup.
+	{
+		err = f.Save(Dictionary{{Key: []byte("info"), Value: []byte("Moon")}}, true)
+		aTest.MustBeNoError(err)
+
+		data, err = os.ReadFile(filePath)
+		aTest.MustBeNoError(err)
+		aTest.MustBeEqual(string(data), "d4:info4:Moone")
+
+		data, err = os.ReadFile(filePath + BackupFileExtension)
+		aTest.MustBeNoError(err)
+		aTest.MustBeEqual(string(data), TestFileBContents)
+
+		var fi os.FileInfo
+		fi, err = os.Stat(filePath)
+		aTest.MustBeNoError(err)
+		aTest.MustBeEqual(fi.Mode().Perm(), os.FileMode(0600))
+	}
+
+	// Test #2. Positive: a new file.
+	{
+		newFilePath := filepath.Join(TestFolder, TestFileCName)
+		err = NewFile(newFilePath).Save(int64(7), true)
+		aTest.MustBeNoError(err)
+
+		data, err = os.ReadFile(newFilePath)
+		aTest.MustBeNoError(err)
+		aTest.MustBeEqual(string(data), "i7e")
+
+		_, err = os.Stat(newFilePath + BackupFileExtension)
+		aTest.MustBeAnError(err)
+	}
+
+	// Test #3. Negative: unsupported value does not touch the file.
+	{
+		err = f.Save(struct{}{}, false)
+		aTest.MustBeAnError(err)
+
+		data, err = os.ReadFile(filePath)
+		aTest.MustBeNoError(err)
+		aTest.MustBeEqual(string(data), "d4:info4:Moone")
+	}
+
+	// No temporary files are left.
+	var entries []os.DirEntry
+	entries, err = os.ReadDir(TestFolder)
+	aTest.MustBeNoError(err)
+	aTest.MustBeEqual(len(entries), 3)
+}
+
+func Test_File_Save_symlink(t *testing.T) {
+	var aTest = tester.New(t)
+
+	var folder = t.TempDir()
+	var targetPath = filepath.Join(folder, "target.torrent")
+	var linkPath = filepath.Join(folder, "link.torrent")
+
+	var err = os.WriteFile(targetPath, []byte("i1e"), 0644)
+	aTest.MustBeNoError(err)
+
+	err = os.Symlink(targetPath, linkPath)
+	if err != nil {
+		t.Skip("symbolic links are not supported:", err)
+	}
+
+	// Test #1. Positive: the target of the link is written.
+	{
+		err = NewFile(linkPath).Save(int64(2), true)
+		aTest.MustBeNoError(err)
+
+		var fi os.FileInfo
+		fi, err = os.Lstat(linkPath)
+		aTest.MustBeNoError(err)
+		aTest.MustBeEqual(fi.Mode()&os.ModeSymlink, os.ModeSymlink)
+
+		var data []byte
+		data, err = os.ReadFile(targetPath)
+		aTest.MustBeNoError(err)
+		aTest.MustBeEqual(string(data), "i2e")
+
+		data, err = os.ReadFile(targetPath + BackupFileExtension)
+		aTest.MustBeNoError(err)
+		aTest.MustBeEqual(string(data), "i1e")
+	}
+
+	// Test #2. Negative: broken link.
+	{
+		var brokenLinkPath = filepath.Join(folder, "broken.torrent")
+		err = os.Symlink(filepath.Join(folder, "none"), brokenLinkPath)
+		aTest.MustBeNoError(err)
+
+		err = NewFile(brokenLinkPath).Save(int64(3), false)
+		aTest.MustBeAnError(err)
+
+		var fi os.FileInfo
+		fi, err = os.Lstat(brokenLinkPath)
+		aTest.MustBeNoError(err)
+		aTest.MustBeEqual(fi.Mode()&os.ModeSymlink, os.ModeSymlink)
+	}
+}
+
+func Test_DecodedObject_WriteFile(t *testing.T) {
+	var aTest = tester.New(t)
+
+	// Test Initialization.
+	createTestFolder(t)
+	createTestFileB(t)
+	filePath := filepath.Join(TestFolder, TestFileBName)
+
+	// Test Finalization.
+	defer func() {
+		deleteTestFolder(t)
+	}()
+
+	var do *DecodedObject
+	var err error
+
+	// Test #1. Positive.
+	{
+		do, err = NewFile(filePath).Parse(true)
+		aTest.MustBeNoError(err)
+
+		do.RawObject, err = Set(do.RawObject, "info", []byte("Moon"))
+		aTest.MustBeNoError(err)
+
+		err = do.WriteFile(false)
+		aTest.MustBeNoError(err)
+		aTest.MustBeEqual(string(do.SourceData), "d4:info4:Moone")
+		aTest.MustBeEqual(do.FileSize, int64(14))
+		aTest.MustBeEqual(do.SourceSHA256, sha256.Sum256([]byte("d4:info4:Moone")))
+
+		do, err = NewFile(filePath).Parse(true)
+		aTest.MustBeNoError(err)
+		aTest.MustBeEqual(string(do.SourceData), "d4:info4:Moone")
+	}
+
+	// Test #2. Negative.
+	{
+		do = &DecodedObject{RawObject: int64(1)}
+		err = do.WriteFile(false)
+		aTest.MustBeAnError(err)
+	}
+}
+
+func Test_DecodedObject_WriteFile_compressed(t *testing.T) {
+	var aTest = tester.New(t)
+
+	var folder = t.TempDir()
+
+	// Test #1. Positive: the compression is kept.
+	for _, compression := range []Compression{CompressionGzip, CompressionZlib} {
+		var filePath = filepath.Join(folder, "file-"+compression.String())
+		err := os.WriteFile(filePath, compressTestData(t, compression, []byte(TestFileBContents)), 0644)
+		aTest.MustBeNoError(err)
+
+		do, err := NewFile(filePath).Parse(true)
+		aTest.MustBeNoError(err)
+
+		do.RawObject, err = Set(do.RawObject, "info", []byte("Moon"))
+		aTest.MustBeNoError(err)
+
+		err = do.WriteFile(false)
+		aTest.MustBeNoError(err)
+		aTest.MustBeEqual(string(do.SourceData), "d4:info4:Moone")
+
+		var data []byte
+		data, err = os.ReadFile(filePath)
+		aTest.MustBeNoError(err)
+		aTest.MustBeEqual(DetectCompression(data), compression)
+		aTest.MustBeEqual(do.FileSize, int64(len(data)))
+
+		do, err = NewFile(filePath).Parse(true)
+		aTest.MustBeNoError(err)
+		aTest.MustBeEqual(string(do.SourceData), "d4:info4:Moone")
+		aTest.MustBeEqual(do.Compression, compression)
+	}
+
+	// Test #2. Negative: 'bzip2' can not be written.
+	{
+		var filePath = filepath.Join(folder, "file-bzip2")
+		var fileData = compressTestData(t, CompressionBzip2, []byte(TestFileBContents))
+		err := os.WriteFile(filePath, fileData, 0644)
+		aTest.MustBeNoError(err)
+
+		do, err := NewFile(filePath).Parse(true)
+		aTest.MustBeNoError(err)
+
+		err = do.WriteFile(false)
+		aTest.MustBeAnError(err)
+		aTest.MustBeEqual(err.Error(), "writing of bzip2 compressed data is not supported")
+
+		var data []byte
+		data, err = os.ReadFile(filePath)
+		aTest.MustBeNoError(err)
+		aTest.MustBeEqual(data, fileData)
+	}
+}
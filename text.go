@@ -0,0 +1,350 @@
+package bencode
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Text notation of 'bencode' values.
+//
+// The notation is meant for writing documents by hand, e.g. test fixtures:
+//
+//	// A tracker response.
+//	{
+//	  "interval": 1800
+//	  "peers": #0a0000011ae1
+//	  "warning message": "Line 1\nLine 2"
+//	  "list": [1, -2, "three", []]
+//	}
+//
+// Integers are written in decimal form. Byte strings are written either as
+// double-quoted strings with the escape sequences of the Go language, e.g.
+// "\x00\n", or as hexadecimal literals starting with the '#' symbol; spaces
+// are not allowed inside a hexadecimal literal. Lists are written in square
+// brackets, dictionaries are written in curly brackets with a colon between
+// a key and a value. Values may be separated by spaces, line breaks or
+// commas. Comments start with '//' and last till the end of the line. The
+// order of dictionary keys is kept as written.
+
+// Special symbols of the text notation.
+const (
+	TextListStart       = '['
+	TextListEnd         = ']'
+	TextDictionaryStart = '{'
+	TextDictionaryEnd   = '}'
+	TextKeyDelimiter    = ':'
+	TextHexPrefix       = '#'
+	TextQuote           = '"'
+	TextSeparator       = ','
+	TextComment         = "//"
+	TextIndent          = "  "
+)
+
+// textParser is a parser of the text notation.
+type textParser struct {
+	src []byte
+	pos int
+}
+
+// ParseText parses a value written in the text notation. The value is
+// returned in the form returned by the decoder, so that it can be passed to
+// the encoder.
+func ParseText(text []byte) (result any, err error) {
+	var p = &textParser{
+		src: text,
+	}
+
+	result, err = p.parseValue()
+	if err != nil {
+		return nil, err
+	}
+
+	p.skipSpace()
+	if p.pos < len(p.src) {
+		return nil, p.syntaxError("unexpected data after the value")
+	}
+
+	return result, nil
+}
+
+// TextToBencode converts a value written in the text notation into a
+// 'bencoded' document.
+func TextToBencode(text []byte) (data []byte, err error) {
+	var value any
+	value, err = ParseText(text)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewEncoder().EncodeAnInterface(value)
+}
+
+// FormatText writes a value in the text notation. Parsing the text gives
+// exactly the same value. Printable byte strings are quoted, other byte
+// strings are written as hexadecimal literals.
+func FormatText(v any) (text string, err error) {
+	var value Value
+	value, err = ToValue(v)
+	if err != nil {
+		return "", err
+	}
+
+	var sb strings.Builder
+	err = appendTextValue(&sb, value, 0)
+	if err != nil {
+		return "", err
+	}
+
+	return sb.String(), nil
+}
+
+// appendTextValue writes a value in the text notation.
+func appendTextValue(sb *strings.Builder, v Value, depth int) (err error) {
+	switch x := v.(type) {
+	case Int:
+		sb.WriteString(strconv.FormatInt(int64(x), 10))
+
+	case String:
+		sb.WriteString(formatTextByteString(x))
+
+	case List:
+		if len(x) == 0 {
+			sb.WriteString("[]")
+			return nil
+		}
+
+		sb.WriteByte(TextListStart)
+		for _, item := range x {
+			writeTextLineStart(sb, depth+1)
+			err = appendTextValue(sb, item, depth+1)
+			if err != nil {
+				return err
+			}
+		}
+		writeTextLineStart(sb, depth)
+		sb.WriteByte(TextListEnd)
+
+	case Dict:
+		if len(x) == 0 {
+			sb.WriteString("{}")
+			return nil
+		}
+
+		sb.WriteByte(TextDictionaryStart)
+		for _, entry := range x {
+			writeTextLineStart(sb, depth+1)
+			sb.WriteString(formatTextByteString(entry.Key))
+			sb.WriteString(string(TextKeyDelimiter) + " ")
+			err = appendTextValue(sb, entry.Value, depth+1)
+			if err != nil {
+				return err
+			}
+		}
+		writeTextLineStart(sb, depth)
+		sb.WriteByte(TextDictionaryEnd)
+
+	default:
+		return errors.New(ErrDataType)
+	}
+
+	return nil
+}
+
+// writeTextLineStart starts a new line with indentation.
+func writeTextLineStart(sb *strings.Builder, depth int) {
+	sb.WriteByte('\n')
+	sb.WriteString(strings.Repeat(TextIndent, depth))
+}
+
+// formatTextByteString writes a byte string in the text notation.
+func formatTextByteString(ba []byte) string {
+	if isPrintableText(ba) {
+		return strconv.Quote(string(ba))
+	}
+
+	return string(TextHexPrefix) + hex.EncodeToString(ba)
+}
+
+// syntaxError creates an error at the current position.
+func (p *textParser) syntaxError(message string) error {
+	var line = bytes.Count(p.src[:p.pos], []byte{'\n'}) + 1
+	var column = p.pos - bytes.LastIndexByte(p.src[:p.pos], '\n')
+
+	return fmt.Errorf(ErrFTextSyntax, line, column, message)
+}
+
+// skipSpace skips spaces, separators and comments.
+func (p *textParser) skipSpace() {
+	for p.pos < len(p.src) {
+		switch p.src[p.pos] {
+		case ' ', '\t', '\r', '\n', TextSeparator:
+			p.pos++
+
+		case TextComment[0]:
+			if !bytes.HasPrefix(p.src[p.pos:], []byte(TextComment)) {
+				return
+			}
+
+			var end = bytes.IndexByte(p.src[p.pos:], '\n')
+			if end < 0 {
+				p.pos = len(p.src)
+				return
+			}
+			p.pos += end
+
+		default:
+			return
+		}
+	}
+}
+
+// parseValue parses a value.
+func (p *textParser) parseValue() (result any, err error) {
+	p.skipSpace()
+	if p.pos >= len(p.src) {
+		return nil, p.syntaxError("a value is expected")
+	}
+
+	switch b := p.src[p.pos]; {
+	case b == TextListStart:
+		return p.parseList()
+
+	case b == TextDictionaryStart:
+		return p.parseDictionary()
+
+	case (b == TextQuote) || (b == TextHexPrefix):
+		return p.parseByteString()
+
+	case isByteAsciiNumeric(b):
+		return p.parseInteger()
+	}
+
+	return nil, p.syntaxError("unexpected symbol")
+}
+
+// parseByteString parses a quoted string or a hexadecimal literal.
+func (p *textParser) parseByteString() (ba []byte, err error) {
+	if p.pos >= len(p.src) {
+		return nil, p.syntaxError("a byte string is expected")
+	}
+
+	if p.src[p.pos] == TextHexPrefix {
+		var start = p.pos + 1
+		var end = start
+		for (end < len(p.src)) && isHexDigit(p.src[end]) {
+			end++
+		}
+
+		ba, err = hex.DecodeString(string(p.src[start:end]))
+		if (err != nil) || (end == start) {
+			return nil, p.syntaxError("bad hexadecimal literal")
+		}
+
+		p.pos = end
+		return ba, nil
+	}
+
+	if p.src[p.pos] != TextQuote {
+		return nil, p.syntaxError("a byte string is expected")
+	}
+
+	var quoted string
+	quoted, err = strconv.QuotedPrefix(string(p.src[p.pos:]))
+	if err != nil {
+		return nil, p.syntaxError("bad quoted string")
+	}
+
+	var text string
+	text, err = strconv.Unquote(quoted)
+	if err != nil {
+		return nil, p.syntaxError("bad quoted string")
+	}
+
+	p.pos += len(quoted)
+	return []byte(text), nil
+}
+
+// parseInteger parses an integer.
+func (p *textParser) parseInteger() (value int64, err error) {
+	var start = p.pos
+	var end = start
+	for (end < len(p.src)) && isByteAsciiNumeric(p.src[end]) {
+		end++
+	}
+
+	value, err = strconv.ParseInt(string(p.src[start:end]), 10, 64)
+	if err != nil {
+		return 0, p.syntaxError("bad integer")
+	}
+
+	p.pos = end
+	return value, nil
+}
+
+// parseList parses a list.
+func (p *textParser) parseList() (list []any, err error) {
+	list = make([]any, 0)
+	p.pos++
+
+	for {
+		p.skipSpace()
+		if (p.pos < len(p.src)) && (p.src[p.pos] == TextListEnd) {
+			p.pos++
+			return list, nil
+		}
+
+		var item any
+		item, err = p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+
+		list = append(list, item)
+	}
+}
+
+// parseDictionary parses a dictionary.
+func (p *textParser) parseDictionary() (dictionary []DictionaryItem, err error) {
+	dictionary = make([]DictionaryItem, 0)
+	p.pos++
+
+	for {
+		p.skipSpace()
+		if (p.pos < len(p.src)) && (p.src[p.pos] == TextDictionaryEnd) {
+			p.pos++
+			return dictionary, nil
+		}
+
+		var item DictionaryItem
+		item.Key, err = p.parseByteString()
+		if err != nil {
+			return nil, err
+		}
+
+		p.skipSpace()
+		if (p.pos >= len(p.src)) || (p.src[p.pos] != TextKeyDelimiter) {
+			return nil, p.syntaxError("a colon is expected")
+		}
+		p.pos++
+
+		item.Value, err = p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+
+		item.KeyStr = string(item.Key)
+		item.ValueStr = convertInterfaceToString(item.Value)
+		dictionary = append(dictionary, item)
+	}
+}
+
+// isHexDigit checks whether the byte is a hexadecimal digit.
+func isHexDigit(b byte) bool {
+	return ((b >= '0') && (b <= '9')) ||
+		((b >= 'a') && (b <= 'f')) ||
+		((b >= 'A') && (b <= 'F'))
+}
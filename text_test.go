@@ -0,0 +1,100 @@
+package bencode
+
+import (
+	"testing"
+	"time"
+
+	"github.com/vault-thirteen/auxie/tester"
+)
+
+func Test_ParseText(t *testing.T) {
+	var aTest = tester.New(t)
+
+	// Test #1. Positive.
+	{
+		result, err := ParseText([]byte(`
+			// A tracker response.
+			{
+			  "interval": 1800 // Seconds.
+			  "peers": #0a0000011AE1,
+			  "list": [1, -2, "th\x00ree", []]
+			  #ff: {}
+			}
+		`))
+		aTest.MustBeNoError(err)
+		aTest.MustBeEqual(result, []DictionaryItem{
+			{Key: []byte("interval"), Value: int64(1800), KeyStr: "interval"},
+			{Key: []byte("peers"), Value: []byte{0x0a, 0x00, 0x00, 0x01, 0x1a, 0xe1}, KeyStr: "peers", ValueStr: "\x0a\x00\x00\x01\x1a\xe1"},
+			{Key: []byte("list"), Value: []any{int64(1), int64(-2), []byte("th\x00ree"), []any{}}, KeyStr: "list"},
+			{Key: []byte{0xff}, Value: []DictionaryItem{}, KeyStr: "\xff"},
+		})
+	}
+
+	// Test #2. Negative.
+	{
+		for _, text := range []string{
+			"", "x", "1 2", "--1", "99999999999999999999", "#", "#abc", "#zz", `"abc`, `"\q"`,
+			"[1", "{", `{"a" 1}`, `{"a":}`, `{1: 2}`, `{"a": 1`, "/ comment",
+		} {
+			_, err := ParseText([]byte(text))
+			aTest.MustBeAnError(err)
+		}
+
+		_, err := ParseText([]byte("[\n  1\n  x\n]"))
+		aTest.MustBeEqual(err.Error(), "text syntax error at line 3, column 3: unexpected symbol")
+	}
+}
+
+func Test_TextToBencode(t *testing.T) {
+	var aTest = tester.New(t)
+
+	data, err := TextToBencode([]byte(`{"b": "text", "a": [#0001 7]} // Non-canonical order is kept.`))
+	aTest.MustBeNoError(err)
+	aTest.MustBeEqual(string(data), "d1:b4:text1:al2:\x00\x01i7eee")
+
+	_, err = TextToBencode([]byte("{"))
+	aTest.MustBeAnError(err)
+}
+
+func Test_FormatText(t *testing.T) {
+	var aTest = tester.New(t)
+
+	// Test #1. Exact round trip.
+	{
+		var source = []byte("d8:announce5:url\n\t4:infod6:lengthi-12e6:pieces3:\x00\x01\xffe4:listli1e0:ledeee")
+		value, err := DecodeBytes[any](source)
+		aTest.MustBeNoError(err)
+
+		text, err := FormatText(value)
+		aTest.MustBeNoError(err)
+		aTest.MustBeEqual(text, `{
+  "announce": "url\n\t"
+  "info": {
+    "length": -12
+    "pieces": #0001ff
+  }
+  "list": [
+    1
+    ""
+    []
+    {}
+  ]
+}`)
+
+		data, err := TextToBencode([]byte(text))
+		aTest.MustBeNoError(err)
+		aTest.MustBeEqual(data, source)
+	}
+
+	// Test #2. Negative.
+	{
+		_, err := FormatText(time.Time{})
+		aTest.MustBeAnError(err)
+
+		_, err = FormatText(List{nil})
+		aTest.MustBeAnError(err)
+
+		_, err = FormatText(Dict{{Key: []byte("a"), Value: nil}})
+		aTest.MustBeAnError(err)
+	}
+}
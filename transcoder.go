@@ -0,0 +1,234 @@
+package bencode
+
+import (
+	"bufio"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// TranscoderTextMaxLength is the maximal length of a byte string which the
+// streaming transcoder keeps in memory to check whether it is a UTF-8 text.
+// Longer byte strings are always written as tagged base64 data, which keeps
+// the used memory constant. Such output is still lossless and is understood
+// by FromJSON.
+const TranscoderTextMaxLength = 64 * 1024
+
+// transcoder converts a 'bencoded' stream into JSON token by token.
+type transcoder struct {
+	decoder *Decoder
+	writer  *bufio.Writer
+	buffer  []byte
+	output  []byte
+}
+
+// TranscodeToJSON reads a stream of 'bencoded' values and writes each of them
+// as JSON on a separate line. The values are never decoded as a whole, so that
+// the used memory does not depend on the size of the data. The JSON format
+// is the one used by ToJSON.
+func TranscodeToJSON(r io.Reader, w io.Writer) (err error) {
+	var t = &transcoder{
+		decoder: NewDecoder(bufio.NewReader(r)),
+		writer:  bufio.NewWriter(w),
+	}
+
+	for {
+		_, err = t.decoder.reader.Peek(1)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		err = t.transcodeValue()
+		if err != nil {
+			return err
+		}
+
+		err = t.writer.WriteByte('\n')
+		if err != nil {
+			return err
+		}
+	}
+
+	return t.writer.Flush()
+}
+
+// transcodeValue converts a value, including its sub-values.
+func (t *transcoder) transcodeValue() (err error) {
+	var b byte
+	b, err = t.decoder.reader.ReadByte()
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case b == HeaderDictionary:
+		return t.transcodeDictionary()
+
+	case b == HeaderList:
+		return t.transcodeList()
+
+	case b == HeaderInteger:
+		var value int64
+		value, err = t.decoder.readInteger()
+		if err != nil {
+			return err
+		}
+
+		t.output = strconv.AppendInt(t.output[:0], value, 10)
+		_, err = t.writer.Write(t.output)
+		return err
+
+	case isByteNonNegativeAsciiNumeric(b):
+		err = t.decoder.reader.UnreadByte()
+		if err != nil {
+			return err
+		}
+
+		return t.transcodeByteString(false)
+	}
+
+	return fmt.Errorf(ErrFSyntaxErrorAt, []byte{b})
+}
+
+// transcodeList converts a list. We suppose that the header of the list has
+// already been read.
+func (t *transcoder) transcodeList() (err error) {
+	err = t.writer.WriteByte('[')
+	if err != nil {
+		return err
+	}
+
+	for i := 0; ; i++ {
+		var isEnd bool
+		isEnd, err = probeEnd(t.decoder.reader)
+		if err != nil {
+			return err
+		}
+		if isEnd {
+			break
+		}
+
+		if i > 0 {
+			err = t.writer.WriteByte(',')
+			if err != nil {
+				return err
+			}
+		}
+
+		err = t.transcodeValue()
+		if err != nil {
+			return err
+		}
+	}
+
+	return t.writer.WriteByte(']')
+}
+
+// transcodeDictionary converts a dictionary. We suppose that the header of the
+// dictionary has already been read.
+func (t *transcoder) transcodeDictionary() (err error) {
+	err = t.writer.WriteByte('{')
+	if err != nil {
+		return err
+	}
+
+	for i := 0; ; i++ {
+		var isEnd bool
+		isEnd, err = probeEnd(t.decoder.reader)
+		if err != nil {
+			return err
+		}
+		if isEnd {
+			break
+		}
+
+		if i > 0 {
+			err = t.writer.WriteByte(',')
+			if err != nil {
+				return err
+			}
+		}
+
+		err = t.transcodeByteString(true)
+		if err != nil {
+			return err
+		}
+
+		err = t.writer.WriteByte(':')
+		if err != nil {
+			return err
+		}
+
+		err = t.transcodeValue()
+		if err != nil {
+			return err
+		}
+	}
+
+	return t.writer.WriteByte('}')
+}
+
+// transcodeByteString converts a byte string or a dictionary key.
+func (t *transcoder) transcodeByteString(isKey bool) (err error) {
+	var byteStringLen uint
+	byteStringLen, err = t.decoder.readByteStringSizeHeader()
+	if err != nil {
+		return err
+	}
+
+	if byteStringLen > TranscoderTextMaxLength {
+		return t.transcodeLongByteString(int64(byteStringLen), isKey)
+	}
+
+	if cap(t.buffer) < int(byteStringLen) {
+		t.buffer = make([]byte, byteStringLen)
+	}
+	var ba = t.buffer[:byteStringLen]
+
+	_, err = io.ReadFull(t.decoder.reader, ba)
+	if err != nil {
+		return err
+	}
+
+	if isKey {
+		t.output = appendJSONString(t.output[:0], encodeJSONKey(ba))
+	} else {
+		t.output = appendJSONByteString(t.output[:0], ba)
+	}
+
+	_, err = t.writer.Write(t.output)
+	return err
+}
+
+// transcodeLongByteString converts a long byte string or a dictionary key
+// into tagged base64 data without keeping it in memory.
+func (t *transcoder) transcodeLongByteString(byteStringLen int64, isKey bool) (err error) {
+	var prefix, postfix = `{"` + JSONTagBytes + `":"`, `"}`
+	if isKey {
+		prefix, postfix = `"`+JSONTagKeyBase64, `"`
+	}
+
+	_, err = t.writer.WriteString(prefix)
+	if err != nil {
+		return err
+	}
+
+	var encoder = base64.NewEncoder(base64.StdEncoding, t.writer)
+	_, err = io.CopyN(encoder, t.decoder.reader, byteStringLen)
+	if err != nil {
+		return err
+	}
+
+	err = encoder.Close()
+	if err != nil {
+		return err
+	}
+
+	_, err = t.writer.WriteString(postfix)
+	return err
+}
@@ -0,0 +1,63 @@
+package bencode
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/vault-thirteen/auxie/tester"
+)
+
+func Test_TranscodeToJSON(t *testing.T) {
+	var aTest = tester.New(t)
+
+	// Test #1. The same output as of the tree converter.
+	{
+		var source = "d4:infod6:lengthi12e6:pieces3:\x00\x01\xffe4:listli-1e0:lee6:$bytes1:x1:\xabdee"
+		var output bytes.Buffer
+		err := TranscodeToJSON(strings.NewReader(source), &output)
+		aTest.MustBeNoError(err)
+
+		expected, err := BencodeToJSON([]byte(source))
+		aTest.MustBeNoError(err)
+		aTest.MustBeEqual(output.String(), string(expected)+"\n")
+	}
+
+	// Test #2. A stream of values and long byte strings.
+	{
+		var long = strings.Repeat("a", TranscoderTextMaxLength+1)
+		var source = "i1e" + "d" + "65537:" + long + "65537:" + long + "e"
+		var output bytes.Buffer
+		err := TranscodeToJSON(strings.NewReader(source), &output)
+		aTest.MustBeNoError(err)
+
+		var lines = strings.Split(output.String(), "\n")
+		aTest.MustBeEqual(len(lines), 3)
+		aTest.MustBeEqual(lines[0], "1")
+
+		// Lossless conversion back.
+		encoded, err := JSONToBencode([]byte(lines[1]))
+		aTest.MustBeNoError(err)
+		aTest.MustBeEqual(string(encoded), source[3:])
+	}
+
+	// Test #3. Empty input.
+	{
+		var output bytes.Buffer
+		err := TranscodeToJSON(strings.NewReader(""), &output)
+		aTest.MustBeNoError(err)
+		aTest.MustBeEqual(output.Len(), 0)
+	}
+
+	// Test #4. Negative.
+	{
+		for _, source := range []string{"x", "i1", "l", "li1e", "d", "d1:a", "d1:ai1e", "di1ei1ee", "5:abc", "65537:abc", "dl"} {
+			var output bytes.Buffer
+			err := TranscodeToJSON(strings.NewReader(source), &output)
+			aTest.MustBeAnError(err)
+		}
+
+		err := TranscodeToJSON(strings.NewReader("i1e"), failingWriter{})
+		aTest.MustBeAnError(err)
+	}
+}
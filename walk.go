@@ -0,0 +1,114 @@
+package bencode
+
+import (
+	"errors"
+)
+
+// SkipSubtree is returned by a WalkFunc to skip the children of the current
+// node. It is not returned as an error by Walk.
+var SkipSubtree = errors.New(ErrSkipSubtree)
+
+// WalkFunc is a function called by Walk for each node of a tree. Path is the
+// key path of the node, depth of the root node is zero.
+type WalkFunc func(path string, depth int, value any) (err error)
+
+// TransformFunc is a function called by Transform for each node of a tree.
+// It returns the value which replaces the node. A nil result removes the node
+// from its parent list or dictionary.
+type TransformFunc func(path string, depth int, value any) (result any, err error)
+
+// Walk visits every node of a tree in the depth-first order, parents before
+// children. The tree is given in the form returned by the decoder:
+// []DictionaryItem for dictionaries and []any for lists; keys of dictionaries
+// are not nodes. If the function returns SkipSubtree, the children of the
+// node are not visited; any other error stops the walk and is returned.
+func Walk(v any, fn WalkFunc) (err error) {
+	err = walkNode("", 0, v, fn)
+	if errors.Is(err, SkipSubtree) {
+		return nil
+	}
+
+	return err
+}
+
+// walkNode visits a node and its children.
+func walkNode(path string, depth int, node any, fn WalkFunc) (err error) {
+	err = fn(path, depth, node)
+	if err != nil {
+		return err
+	}
+
+	switch x := node.(type) {
+	case []DictionaryItem:
+		for _, item := range x {
+			err = walkNode(appendPathKey(path, item.Key), depth+1, item.Value, fn)
+			if (err != nil) && !errors.Is(err, SkipSubtree) {
+				return err
+			}
+		}
+
+	case []any:
+		for i, item := range x {
+			err = walkNode(appendPathIndex(path, i), depth+1, item, fn)
+			if (err != nil) && !errors.Is(err, SkipSubtree) {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// Transform rebuilds a tree replacing its nodes with the values returned by
+// the function. The function is called for children before their parents, a
+// parent receives the already rebuilt children. The original tree is not
+// changed. See Walk for the form of the tree.
+func Transform(v any, fn TransformFunc) (result any, err error) {
+	return transformNode("", 0, v, fn)
+}
+
+// transformNode rebuilds a node and its children.
+func transformNode(path string, depth int, node any, fn TransformFunc) (result any, err error) {
+	switch x := node.(type) {
+	case []DictionaryItem:
+		var dictionary = make([]DictionaryItem, 0, len(x))
+		for _, item := range x {
+			var value any
+			value, err = transformNode(appendPathKey(path, item.Key), depth+1, item.Value, fn)
+			if err != nil {
+				return nil, err
+			}
+
+			if value == nil {
+				continue
+			}
+
+			dictionary = append(dictionary, DictionaryItem{
+				Key:      item.Key,
+				Value:    value,
+				KeyStr:   string(item.Key),
+				ValueStr: convertInterfaceToString(value),
+			})
+		}
+		node = dictionary
+
+	case []any:
+		var list = make([]any, 0, len(x))
+		for i, item := range x {
+			var value any
+			value, err = transformNode(appendPathIndex(path, i), depth+1, item, fn)
+			if err != nil {
+				return nil, err
+			}
+
+			if value == nil {
+				continue
+			}
+
+			list = append(list, value)
+		}
+		node = list
+	}
+
+	return fn(path, depth, node)
+}
@@ -0,0 +1,112 @@
+package bencode
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/vault-thirteen/auxie/tester"
+)
+
+func Test_Walk(t *testing.T) {
+	var aTest = tester.New(t)
+
+	var tree, err = DecodeBytes[any]([]byte("d1:ai1e1:bli2eli3eee1:cd1:di4eee"))
+	aTest.MustBeNoError(err)
+
+	// Test #1. All the nodes.
+	{
+		var visited []string
+		err = Walk(tree, func(path string, depth int, value any) error {
+			visited = append(visited, fmt.Sprintf("%d:%s", depth, path))
+			return nil
+		})
+		aTest.MustBeNoError(err)
+		aTest.MustBeEqual(visited, []string{"0:", "1:a", "1:b", "2:b[0]", "2:b[1]", "3:b[1][0]", "1:c", "2:c.d"})
+	}
+
+	// Test #2. Skipped subtrees.
+	{
+		var visited []string
+		err = Walk(tree, func(path string, depth int, value any) error {
+			visited = append(visited, path)
+			if (path == "b") || (path == "c") {
+				return SkipSubtree
+			}
+			return nil
+		})
+		aTest.MustBeNoError(err)
+		aTest.MustBeEqual(visited, []string{"", "a", "b", "c"})
+
+		err = Walk(tree, func(path string, depth int, value any) error {
+			return SkipSubtree
+		})
+		aTest.MustBeNoError(err)
+	}
+
+	// Test #3. Stop.
+	{
+		var stop = errors.New("stop")
+		var count int
+		err = Walk(tree, func(path string, depth int, value any) error {
+			count++
+			if path == "b[0]" {
+				return stop
+			}
+			return nil
+		})
+		aTest.MustBeEqual(err, stop)
+		aTest.MustBeEqual(count, 4)
+	}
+}
+
+func Test_Transform(t *testing.T) {
+	var aTest = tester.New(t)
+
+	var tree, err = DecodeBytes[any]([]byte("d1:ai1e1:bli2eli3eee6:secret3:xyze"))
+	aTest.MustBeNoError(err)
+
+	// Test #1. Replacement and removal.
+	{
+		result, err := Transform(tree, func(path string, depth int, value any) (any, error) {
+			if path == "secret" {
+				return nil, nil
+			}
+
+			if i, ok := value.(int64); ok {
+				return i * 10, nil
+			}
+
+			return value, nil
+		})
+		aTest.MustBeNoError(err)
+
+		encoded, err := NewEncoder().EncodeAnInterface(result)
+		aTest.MustBeNoError(err)
+		aTest.MustBeEqual(string(encoded), "d1:ai10e1:bli20eli30eeee")
+
+		// The original tree is not changed.
+		encoded, err = NewEncoder().EncodeAnInterface(tree)
+		aTest.MustBeNoError(err)
+		aTest.MustBeEqual(string(encoded), "d1:ai1e1:bli2eli3eee6:secret3:xyze")
+	}
+
+	// Test #2. Error.
+	{
+		_, err = Transform(tree, func(path string, depth int, value any) (any, error) {
+			if depth == 3 {
+				return nil, errors.New("error")
+			}
+			return value, nil
+		})
+		aTest.MustBeAnError(err)
+
+		_, err = Transform(tree, func(path string, depth int, value any) (any, error) {
+			if path == "a" {
+				return nil, errors.New("error")
+			}
+			return value, nil
+		})
+		aTest.MustBeAnError(err)
+	}
+}